@@ -0,0 +1,162 @@
+package cfsecurity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	clients "github.com/cloudfoundry-community/go-cf-clients-helper/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+)
+
+type cfsecurityCurrentUserDataSource struct {
+	client  *client.Client
+	config  *clients.Config
+	session *clients.Session
+}
+
+var _ datasource.DataSource = &cfsecurityCurrentUserDataSource{}
+var _ datasource.DataSourceWithConfigure = &cfsecurityCurrentUserDataSource{}
+
+func NewCFSecurityCurrentUserDataSource(config *clients.Config, session *clients.Session) datasource.DataSource {
+	return &cfsecurityCurrentUserDataSource{
+		config:  config,
+		session: session,
+	}
+}
+
+type cfsecurityCurrentUserDataSourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	UserName        types.String `tfsdk:"user_name"`
+	Origin          types.String `tfsdk:"origin"`
+	ClientID        types.String `tfsdk:"client_id"`
+	Scopes          []string     `tfsdk:"scopes"`
+	IsAdmin         types.Bool   `tfsdk:"is_admin"`
+	ExpiresAt       types.String `tfsdk:"expires_at"`
+	ManagedOrgIDs   []string     `tfsdk:"managed_org_ids"`
+	ManagedSpaceIDs []string     `tfsdk:"managed_space_ids"`
+}
+
+func (d *cfsecurityCurrentUserDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_current_user"
+}
+
+func (d *cfsecurityCurrentUserDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The user guid, or the client id when authenticated with a client",
+				Computed:    true,
+			},
+			"user_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"origin": schema.StringAttribute{
+				Computed: true,
+			},
+			"client_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"scopes": schema.ListAttribute{
+				Description: "Scopes granted to the access token",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"is_admin": schema.BoolAttribute{
+				Description: "True if the user has the cloud_controller.admin scope",
+				Computed:    true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "Expiration date of the access token (RFC3339)",
+				Computed:    true,
+			},
+			"managed_org_ids": schema.ListAttribute{
+				Description: "Guids of the orgs where the user is org manager",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"managed_space_ids": schema.ListAttribute{
+				Description: "Guids of the spaces where the user is space manager",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *cfsecurityCurrentUserDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clt, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clt
+}
+
+func (d *cfsecurityCurrentUserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data cfsecurityCurrentUserDataSourceModel
+
+	err := refreshTokenIfExpired(d.client, d.config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to refresh token: %s", err),
+		)
+		return
+	}
+
+	claims, err := getClaimsFromToken(*d.client.GetAccessToken())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to decode access token: %s", err),
+		)
+		return
+	}
+
+	userIsAdmin, err := d.client.CurrentUserIsAdmin()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to check if user is admin: %s", err),
+		)
+		return
+	}
+
+	managedOrgIDs, managedSpaceIDs, err := getUserManagedGUIDs(d.session.V3(), claims.Sub)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get roles of user %s: %s", claims.Sub, err),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(claims.Sub)
+	data.UserName = types.StringValue(claims.UserName)
+	data.Origin = types.StringValue(claims.Origin)
+	data.ClientID = types.StringValue(claims.ClientID)
+	data.Scopes = claims.Scopes
+	if data.Scopes == nil {
+		data.Scopes = make([]string, 0)
+	}
+	data.IsAdmin = types.BoolValue(userIsAdmin)
+	data.ExpiresAt = types.StringValue(time.Unix(int64(claims.Exp), 0).UTC().Format(time.RFC3339))
+	data.ManagedOrgIDs = managedOrgIDs
+	data.ManagedSpaceIDs = managedSpaceIDs
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,308 @@
+package cfsecurity
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	clients "github.com/cloudfoundry-community/go-cf-clients-helper/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+	"github.com/thoas/go-funk"
+)
+
+type cfsecurityEgressCheckDataSource struct {
+	client  *client.Client
+	config  *clients.Config
+	session *clients.Session
+}
+
+var _ datasource.DataSource = &cfsecurityEgressCheckDataSource{}
+var _ datasource.DataSourceWithConfigure = &cfsecurityEgressCheckDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &cfsecurityEgressCheckDataSource{}
+
+func NewCFSecurityEgressCheckDataSource(config *clients.Config, session *clients.Session) datasource.DataSource {
+	return &cfsecurityEgressCheckDataSource{
+		config:  config,
+		session: session,
+	}
+}
+
+type cfsecurityEgressCheckDataSourceModel struct {
+	SpaceID       types.String                  `tfsdk:"space_id"`
+	Destination   types.String                  `tfsdk:"destination"`
+	Port          types.Int64                   `tfsdk:"port"`
+	Protocol      types.String                  `tfsdk:"protocol"`
+	Type          types.Int64                   `tfsdk:"type"`
+	Code          types.Int64                   `tfsdk:"code"`
+	Lifecycle     types.String                  `tfsdk:"lifecycle"`
+	Allowed       types.Bool                    `tfsdk:"allowed"`
+	AsgIDs        []string                      `tfsdk:"asg_ids"`
+	AsgNames      []string                      `tfsdk:"asg_names"`
+	MatchingRules []cfsecurityMatchingRuleModel `tfsdk:"matching_rules"`
+	Explanation   types.String                  `tfsdk:"explanation"`
+}
+
+type cfsecurityMatchingRuleModel struct {
+	AsgID       types.String `tfsdk:"asg_id"`
+	AsgName     types.String `tfsdk:"asg_name"`
+	Protocol    types.String `tfsdk:"protocol"`
+	Destination types.String `tfsdk:"destination"`
+	Ports       types.String `tfsdk:"ports"`
+	Type        types.Int64  `tfsdk:"type"`
+	Code        types.Int64  `tfsdk:"code"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (d *cfsecurityEgressCheckDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_egress_check"
+}
+
+func (d *cfsecurityEgressCheckDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"space_id": schema.StringAttribute{
+				Description: "The space guid",
+				Required:    true,
+				Validators: []validator.String{
+					guidValidator{},
+				},
+			},
+			"destination": schema.StringAttribute{
+				Description: "The destination ip to check",
+				Required:    true,
+			},
+			"port": schema.Int64Attribute{
+				Description: "The destination port to check, required for tcp and udp",
+				Optional:    true,
+			},
+			"protocol": schema.StringAttribute{
+				Description: "The protocol to check: tcp, udp, icmp, icmpv6 or all",
+				Required:    true,
+			},
+			"type": schema.Int64Attribute{
+				Description: "The icmp type to check (default: -1 which only matches rules allowing all types)",
+				Optional:    true,
+			},
+			"code": schema.Int64Attribute{
+				Description: "The icmp code to check (default: -1 which only matches rules allowing all codes)",
+				Optional:    true,
+			},
+			"lifecycle": schema.StringAttribute{
+				Description: "Lifecycle to check, either running or staging (default: running)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"allowed": schema.BoolAttribute{
+				Description: "True if the destination is reachable from the space",
+				Computed:    true,
+			},
+			"asg_ids": schema.ListAttribute{
+				Description: "Guids of the security groups allowing the destination",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"asg_names": schema.ListAttribute{
+				Description: "Names of the security groups allowing the destination",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"matching_rules": schema.ListNestedAttribute{
+				Description: "Rules allowing the destination",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"asg_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"asg_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"protocol": schema.StringAttribute{
+							Computed: true,
+						},
+						"destination": schema.StringAttribute{
+							Computed: true,
+						},
+						"ports": schema.StringAttribute{
+							Computed: true,
+						},
+						"type": schema.Int64Attribute{
+							Computed: true,
+						},
+						"code": schema.Int64Attribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"explanation": schema.StringAttribute{
+				Description: "Human readable explanation of the result",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *cfsecurityEgressCheckDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clt, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clt
+}
+
+func (d *cfsecurityEgressCheckDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var configData cfsecurityEgressCheckDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &configData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !isValidLifecycle(configData.Lifecycle) {
+		resp.Diagnostics.AddAttributeError(path.Root("lifecycle"), "Attribute Error", "\"lifecycle\" must be either \"running\" or \"staging\".")
+	}
+
+	if !configData.Destination.IsUnknown() && !configData.Destination.IsNull() {
+		if _, err := netip.ParseAddr(configData.Destination.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("destination"), "Attribute Error", "\"destination\" must be a single ip address.")
+		}
+	}
+
+	if configData.Protocol.IsUnknown() || configData.Protocol.IsNull() {
+		return
+	}
+	switch strings.ToLower(configData.Protocol.ValueString()) {
+	case protocolTCP, protocolUDP:
+		if configData.Port.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("port"), "Attribute Error", "\"port\" must be provided for tcp and udp protocols.")
+		} else if !configData.Port.IsUnknown() && (configData.Port.ValueInt64() < 1 || configData.Port.ValueInt64() > 65535) {
+			resp.Diagnostics.AddAttributeError(path.Root("port"), "Attribute Error", "\"port\" must be between 1 and 65535.")
+		}
+	case protocolICMP, protocolICMPv6, protocolAll:
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("protocol"), "Attribute Error", "\"protocol\" must be one of tcp, udp, icmp, icmpv6 or all.")
+	}
+}
+
+func (d *cfsecurityEgressCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data cfsecurityEgressCheckDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Lifecycle.ValueString() == "" {
+		data.Lifecycle = types.StringValue(lifecycleRunning)
+	}
+	protocol := strings.ToLower(data.Protocol.ValueString())
+	icmpType, icmpCode := icmpAny, icmpAny
+	if !data.Type.IsNull() {
+		icmpType = int(data.Type.ValueInt64())
+	}
+	if !data.Code.IsNull() {
+		icmpCode = int(data.Code.ValueInt64())
+	}
+	addr, err := netip.ParseAddr(data.Destination.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("destination"), "Attribute Error", fmt.Sprintf("Invalid destination: %s", err))
+		return
+	}
+	addr = addr.Unmap()
+
+	secGroups, err := getSpaceSecurityGroups(d.session.V3(), data.SpaceID.ValueString(), data.Lifecycle.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get security groups of space %s : %s", data.SpaceID.ValueString(), err),
+		)
+		return
+	}
+
+	data.AsgIDs = make([]string, 0)
+	data.AsgNames = make([]string, 0)
+	data.MatchingRules = make([]cfsecurityMatchingRuleModel, 0)
+	for _, secGroup := range secGroups {
+		rules, err := parseSecurityGroupRules(secGroup)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to parse security group rules : %s", err),
+			)
+			return
+		}
+		for _, rule := range rules {
+			if !rule.allows(protocol, addr, uint16(data.Port.ValueInt64()), icmpType, icmpCode) {
+				continue
+			}
+			data.MatchingRules = append(data.MatchingRules, newMatchingRuleModel(secGroup.GUID, secGroup.Name, rule))
+			if !funk.ContainsString(data.AsgIDs, secGroup.GUID) {
+				data.AsgIDs = append(data.AsgIDs, secGroup.GUID)
+				data.AsgNames = append(data.AsgNames, secGroup.Name)
+			}
+		}
+	}
+
+	target := fmt.Sprintf("%s/%s", addr, protocol)
+	if protocol == protocolTCP || protocol == protocolUDP {
+		target = fmt.Sprintf("%s:%d/%s", addr, data.Port.ValueInt64(), protocol)
+	}
+	data.Allowed = types.BoolValue(len(data.MatchingRules) > 0)
+	if data.Allowed.ValueBool() {
+		data.Explanation = types.StringValue(fmt.Sprintf(
+			"%s is reachable from space %s during %s, allowed by %d rule(s) of security group(s) %s.",
+			target, data.SpaceID.ValueString(), data.Lifecycle.ValueString(), len(data.MatchingRules), strings.Join(data.AsgNames, ", "),
+		))
+	} else {
+		data.Explanation = types.StringValue(fmt.Sprintf(
+			"%s is not reachable from space %s during %s, none of the rules of the %d security group(s) applying to the space allow it.",
+			target, data.SpaceID.ValueString(), data.Lifecycle.ValueString(), len(secGroups),
+		))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func newMatchingRuleModel(asgID, asgName string, rule egressRule) cfsecurityMatchingRuleModel {
+	model := cfsecurityMatchingRuleModel{
+		AsgID:       types.StringValue(asgID),
+		AsgName:     types.StringValue(asgName),
+		Protocol:    types.StringValue(rule.Protocol),
+		Destination: types.StringValue(rule.Destination()),
+		Ports:       types.StringNull(),
+		Type:        types.Int64Null(),
+		Code:        types.Int64Null(),
+		Description: types.StringValue(rule.Description),
+	}
+	if rule.hasPorts() {
+		model.Ports = types.StringValue(formatPorts(rule.Ports))
+	}
+	if rule.hasICMP() {
+		model.Type = types.Int64Value(int64(rule.Type))
+		model.Code = types.Int64Value(int64(rule.Code))
+	}
+	return model
+}
@@ -0,0 +1,127 @@
+package cfsecurity
+
+import (
+	"context"
+	"fmt"
+
+	clients "github.com/cloudfoundry-community/go-cf-clients-helper/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+)
+
+type cfsecurityOrgDataSource struct {
+	client *client.Client
+	config *clients.Config
+}
+
+var _ datasource.DataSource = &cfsecurityOrgDataSource{}
+var _ datasource.DataSourceWithConfigure = &cfsecurityOrgDataSource{}
+
+func NewCFSecurityOrgDataSource(config *clients.Config) datasource.DataSource {
+	return &cfsecurityOrgDataSource{
+		config: config,
+	}
+}
+
+type cfsecurityOrgDataSourceModel struct {
+	Name        types.String      `tfsdk:"name"`
+	Id          types.String      `tfsdk:"id"`
+	Labels      map[string]string `tfsdk:"labels"`
+	Annotations map[string]string `tfsdk:"annotations"`
+}
+
+func (d *cfsecurityOrgDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_org"
+}
+
+func (d *cfsecurityOrgDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "The org name",
+				Required:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The org guid",
+				Computed:    true,
+			},
+			"labels": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"annotations": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *cfsecurityOrgDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clt, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clt
+}
+
+func (d *cfsecurityOrgDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data cfsecurityOrgDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := refreshTokenIfExpired(d.client, d.config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to refresh token: %s", err),
+		)
+		return
+	}
+
+	// spaces can not be filtered by org name, org is looked up in organizations included with spaces,
+	// an org without any space can not be found this way
+	spaces, err := d.client.GetSpacesWithOrg(nil, 0)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get org %s: %s", data.Name.ValueString(), err),
+		)
+		return
+	}
+
+	for _, org := range spaces.Included.Organizations {
+		if org.Name != data.Name.ValueString() {
+			continue
+		}
+
+		data.Id = types.StringValue(org.GUID)
+		data.Labels, data.Annotations = metadataToMaps(org.Metadata)
+
+		// Save data into Terraform state
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	resp.Diagnostics.AddError(
+		"Client Error",
+		fmt.Sprintf("Org %s not found", data.Name.ValueString()),
+	)
+}
@@ -0,0 +1,214 @@
+package cfsecurity
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3/constant"
+	clients "github.com/cloudfoundry-community/go-cf-clients-helper/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+)
+
+type cfsecurityOrgManagersDataSource struct {
+	client  *client.Client
+	config  *clients.Config
+	session *clients.Session
+}
+
+var _ datasource.DataSource = &cfsecurityOrgManagersDataSource{}
+var _ datasource.DataSourceWithConfigure = &cfsecurityOrgManagersDataSource{}
+
+func NewCFSecurityOrgManagersDataSource(config *clients.Config, session *clients.Session) datasource.DataSource {
+	return &cfsecurityOrgManagersDataSource{
+		config:  config,
+		session: session,
+	}
+}
+
+type cfsecurityOrgManagersDataSourceModel struct {
+	OrgID         types.String             `tfsdk:"org_id"`
+	OrgManagers   []cfsecurityManagerModel `tfsdk:"org_managers"`
+	SpaceManagers []cfsecurityManagerModel `tfsdk:"space_managers"`
+}
+
+type cfsecurityManagerModel struct {
+	UserID   types.String `tfsdk:"user_id"`
+	Username types.String `tfsdk:"username"`
+	RoleType types.String `tfsdk:"role_type"`
+	SpaceID  types.String `tfsdk:"space_id"`
+}
+
+func (d *cfsecurityOrgManagersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_org_managers"
+}
+
+func (d *cfsecurityOrgManagersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	managerAttributes := map[string]schema.Attribute{
+		"user_id": schema.StringAttribute{
+			Description: "The user guid",
+			Computed:    true,
+		},
+		"username": schema.StringAttribute{
+			Description: "The user name, empty for clients",
+			Computed:    true,
+		},
+		"role_type": schema.StringAttribute{
+			Description: "The role type (organization_manager or space_manager)",
+			Computed:    true,
+		},
+		"space_id": schema.StringAttribute{
+			Description: "The space guid for space roles",
+			Computed:    true,
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"org_id": schema.StringAttribute{
+				Description: "The org guid",
+				Required:    true,
+				Validators: []validator.String{
+					guidValidator{},
+				},
+			},
+			"org_managers": schema.ListNestedAttribute{
+				Description: "Org managers of the org",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: managerAttributes,
+				},
+			},
+			"space_managers": schema.ListNestedAttribute{
+				Description: "Space managers of the spaces of the org",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: managerAttributes,
+				},
+			},
+		},
+	}
+}
+
+func (d *cfsecurityOrgManagersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clt, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clt
+}
+
+func (d *cfsecurityOrgManagersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data cfsecurityOrgManagersDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := refreshTokenIfExpired(d.client, d.config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to refresh token: %s", err),
+		)
+		return
+	}
+
+	orgID := data.OrgID.ValueString()
+	orgRoles, err := d.client.GetOrgManagers(orgID, 0)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get roles of org %s: %s", orgID, err),
+		)
+		return
+	}
+
+	orgManagerGUIDs := make([]string, 0)
+	for _, role := range orgRoles.Resources {
+		if role.Type == string(constant.OrgManagerRole) {
+			orgManagerGUIDs = append(orgManagerGUIDs, role.Relationships.User.Data.GUID)
+		}
+	}
+	users, err := getUsersByGUID(d.session.V3(), orgManagerGUIDs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get users: %s", err),
+		)
+		return
+	}
+	data.OrgManagers = make([]cfsecurityManagerModel, 0)
+	for _, userGUID := range orgManagerGUIDs {
+		data.OrgManagers = append(data.OrgManagers, cfsecurityManagerModel{
+			UserID:   types.StringValue(userGUID),
+			Username: types.StringValue(users[userGUID].Username),
+			RoleType: types.StringValue(string(constant.OrgManagerRole)),
+			SpaceID:  types.StringNull(),
+		})
+	}
+
+	spaces, err := d.client.GetSpacesWithOrg([]ccv3.Query{{Key: ccv3.OrganizationGUIDFilter, Values: []string{orgID}}}, 0)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get spaces of org %s: %s", orgID, err),
+		)
+		return
+	}
+	spaceGUIDs := make([]string, 0)
+	for _, space := range spaces.Resources {
+		spaceGUIDs = append(spaceGUIDs, space.GUID)
+	}
+
+	data.SpaceManagers = make([]cfsecurityManagerModel, 0)
+	for _, chunk := range chunkStrings(spaceGUIDs, 50) {
+		roles, included, _, err := d.session.V3().GetRoles(
+			ccv3.Query{Key: ccv3.SpaceGUIDFilter, Values: chunk},
+			ccv3.Query{Key: ccv3.RoleTypesFilter, Values: []string{string(constant.SpaceManagerRole)}},
+			ccv3.Query{Key: ccv3.Include, Values: []string{"user"}},
+		)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to get roles of spaces of org %s: %s", orgID, err),
+			)
+			return
+		}
+		for _, role := range roles {
+			username := ""
+			for _, user := range included.Users {
+				if user.GUID == role.UserGUID {
+					username = user.Username
+					break
+				}
+			}
+			data.SpaceManagers = append(data.SpaceManagers, cfsecurityManagerModel{
+				UserID:   types.StringValue(role.UserGUID),
+				Username: types.StringValue(username),
+				RoleType: types.StringValue(string(role.Type)),
+				SpaceID:  types.StringValue(role.SpaceGUID),
+			})
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
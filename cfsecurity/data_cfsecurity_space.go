@@ -0,0 +1,175 @@
+package cfsecurity
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3/constant"
+	clients "github.com/cloudfoundry-community/go-cf-clients-helper/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+)
+
+type cfsecuritySpaceDataSource struct {
+	client *client.Client
+	config *clients.Config
+}
+
+var _ datasource.DataSource = &cfsecuritySpaceDataSource{}
+var _ datasource.DataSourceWithConfigure = &cfsecuritySpaceDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &cfsecuritySpaceDataSource{}
+
+func NewCFSecuritySpaceDataSource(config *clients.Config) datasource.DataSource {
+	return &cfsecuritySpaceDataSource{
+		config: config,
+	}
+}
+
+type cfsecuritySpaceDataSourceModel struct {
+	Name        types.String      `tfsdk:"name"`
+	OrgID       types.String      `tfsdk:"org_id"`
+	OrgName     types.String      `tfsdk:"org_name"`
+	Id          types.String      `tfsdk:"id"`
+	Labels      map[string]string `tfsdk:"labels"`
+	Annotations map[string]string `tfsdk:"annotations"`
+}
+
+func (d *cfsecuritySpaceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space"
+}
+
+func (d *cfsecuritySpaceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "The space name",
+				Required:    true,
+			},
+			"org_id": schema.StringAttribute{
+				Description: "The org guid of the space, org_id or org_name must be given",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					guidValidator{},
+				},
+			},
+			"org_name": schema.StringAttribute{
+				Description: "The org name of the space, org_id or org_name must be given",
+				Optional:    true,
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The space guid",
+				Computed:    true,
+			},
+			"labels": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"annotations": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *cfsecuritySpaceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clt, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clt
+}
+
+func (d *cfsecuritySpaceDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var configData cfsecuritySpaceDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &configData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if configData.OrgID.IsNull() && configData.OrgName.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("org_id"), "Attribute Error", "\"org_id\" or \"org_name\" must be provided.")
+	}
+	if !configData.OrgID.IsNull() && !configData.OrgName.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("org_id"), "Attribute Error", "only one of \"org_id\" and \"org_name\" can be provided.")
+	}
+}
+
+func (d *cfsecuritySpaceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data cfsecuritySpaceDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := refreshTokenIfExpired(d.client, d.config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to refresh token: %s", err),
+		)
+		return
+	}
+
+	queries := []ccv3.Query{{Key: ccv3.NameFilter, Values: []string{data.Name.ValueString()}}}
+	if !data.OrgID.IsNull() {
+		queries = append(queries, ccv3.Query{Key: ccv3.OrganizationGUIDFilter, Values: []string{data.OrgID.ValueString()}})
+	}
+	spaces, err := d.client.GetSpacesWithOrg(queries, 0)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get space %s: %s", data.Name.ValueString(), err),
+		)
+		return
+	}
+
+	for _, space := range spaces.Resources {
+		orgGUID := space.Relationships[constant.RelationshipTypeOrganization].GUID
+		orgName := ""
+		for _, org := range spaces.Included.Organizations {
+			if org.GUID == orgGUID {
+				orgName = org.Name
+				break
+			}
+		}
+		if !data.OrgName.IsNull() && orgName != data.OrgName.ValueString() {
+			continue
+		}
+
+		data.Id = types.StringValue(space.GUID)
+		data.OrgID = types.StringValue(orgGUID)
+		data.OrgName = types.StringValue(orgName)
+		data.Labels, data.Annotations = metadataToMaps(space.Metadata)
+
+		// Save data into Terraform state
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	resp.Diagnostics.AddError(
+		"Client Error",
+		fmt.Sprintf("Space %s not found", data.Name.ValueString()),
+	)
+}
@@ -0,0 +1,203 @@
+package cfsecurity
+
+import (
+	"context"
+	"fmt"
+
+	clients "github.com/cloudfoundry-community/go-cf-clients-helper/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+)
+
+type cfsecuritySpaceEgressDataSource struct {
+	client  *client.Client
+	config  *clients.Config
+	session *clients.Session
+}
+
+var _ datasource.DataSource = &cfsecuritySpaceEgressDataSource{}
+var _ datasource.DataSourceWithConfigure = &cfsecuritySpaceEgressDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &cfsecuritySpaceEgressDataSource{}
+
+func NewCFSecuritySpaceEgressDataSource(config *clients.Config, session *clients.Session) datasource.DataSource {
+	return &cfsecuritySpaceEgressDataSource{
+		config:  config,
+		session: session,
+	}
+}
+
+type cfsecuritySpaceEgressDataSourceModel struct {
+	SpaceID   types.String                `tfsdk:"space_id"`
+	Lifecycle types.String                `tfsdk:"lifecycle"`
+	Rules     []cfsecurityEgressRuleModel `tfsdk:"rules"`
+}
+
+type cfsecurityEgressRuleModel struct {
+	Protocol    types.String `tfsdk:"protocol"`
+	Destination types.String `tfsdk:"destination"`
+	Ports       types.String `tfsdk:"ports"`
+	Type        types.Int64  `tfsdk:"type"`
+	Code        types.Int64  `tfsdk:"code"`
+	AsgIDs      []string     `tfsdk:"asg_ids"`
+	AsgNames    []string     `tfsdk:"asg_names"`
+}
+
+func (d *cfsecuritySpaceEgressDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_egress"
+}
+
+func (d *cfsecuritySpaceEgressDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"space_id": schema.StringAttribute{
+				Description: "The space guid",
+				Required:    true,
+				Validators: []validator.String{
+					guidValidator{},
+				},
+			},
+			"lifecycle": schema.StringAttribute{
+				Description: "Lifecycle of the security groups to merge, either running or staging (default: running)",
+				Optional:    true,
+				Computed:    true,
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "Normalized egress rules of the space",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"protocol": schema.StringAttribute{
+							Computed: true,
+						},
+						"destination": schema.StringAttribute{
+							Computed: true,
+						},
+						"ports": schema.StringAttribute{
+							Computed: true,
+						},
+						"type": schema.Int64Attribute{
+							Computed: true,
+						},
+						"code": schema.Int64Attribute{
+							Computed: true,
+						},
+						"asg_ids": schema.ListAttribute{
+							Description: "Guids of the security groups allowing this rule",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"asg_names": schema.ListAttribute{
+							Description: "Names of the security groups allowing this rule",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *cfsecuritySpaceEgressDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clt, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = clt
+}
+
+func (d *cfsecuritySpaceEgressDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var configData cfsecuritySpaceEgressDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &configData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !isValidLifecycle(configData.Lifecycle) {
+		resp.Diagnostics.AddAttributeError(path.Root("lifecycle"), "Attribute Error", "\"lifecycle\" must be either \"running\" or \"staging\".")
+	}
+}
+
+func (d *cfsecuritySpaceEgressDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data cfsecuritySpaceEgressDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Lifecycle.ValueString() == "" {
+		data.Lifecycle = types.StringValue(lifecycleRunning)
+	}
+
+	secGroups, err := getSpaceSecurityGroups(d.session.V3(), data.SpaceID.ValueString(), data.Lifecycle.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get security groups of space %s : %s", data.SpaceID.ValueString(), err),
+		)
+		return
+	}
+
+	rules := make([]egressRule, 0)
+	for _, secGroup := range secGroups {
+		secGroupRules, err := parseSecurityGroupRules(secGroup)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to parse security group rules : %s", err),
+			)
+			return
+		}
+		rules = append(rules, secGroupRules...)
+	}
+
+	data.Rules = make([]cfsecurityEgressRuleModel, 0)
+	for _, rule := range normalizeEgressRules(rules) {
+		data.Rules = append(data.Rules, newEgressRuleModel(rule))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func newEgressRuleModel(rule egressRule) cfsecurityEgressRuleModel {
+	model := cfsecurityEgressRuleModel{
+		Protocol:    types.StringValue(rule.Protocol),
+		Destination: types.StringValue(rule.Destination()),
+		Ports:       types.StringNull(),
+		Type:        types.Int64Null(),
+		Code:        types.Int64Null(),
+		AsgIDs:      make([]string, 0),
+		AsgNames:    make([]string, 0),
+	}
+	if rule.hasPorts() {
+		model.Ports = types.StringValue(formatPorts(rule.Ports))
+	}
+	if rule.hasICMP() {
+		model.Type = types.Int64Value(int64(rule.Type))
+		model.Code = types.Int64Value(int64(rule.Code))
+	}
+	for _, source := range rule.Sources {
+		model.AsgIDs = append(model.AsgIDs, source.GUID)
+		model.AsgNames = append(model.AsgNames, source.Name)
+	}
+	return model
+}
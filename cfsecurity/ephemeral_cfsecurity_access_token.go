@@ -0,0 +1,124 @@
+package cfsecurity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	clients "github.com/cloudfoundry-community/go-cf-clients-helper/v2"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+)
+
+type cfsecurityAccessTokenEphemeralResource struct {
+	client *client.Client
+	config *clients.Config
+}
+
+var _ ephemeral.EphemeralResource = &cfsecurityAccessTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &cfsecurityAccessTokenEphemeralResource{}
+
+func NewCFSecurityAccessTokenEphemeralResource(config *clients.Config) ephemeral.EphemeralResource {
+	return &cfsecurityAccessTokenEphemeralResource{
+		config: config,
+	}
+}
+
+type cfsecurityAccessTokenModel struct {
+	AccessToken         types.String `tfsdk:"access_token"`
+	AuthorizationHeader types.String `tfsdk:"authorization_header"`
+	ExpiresAt           types.String `tfsdk:"expires_at"`
+	CFApiURL            types.String `tfsdk:"cf_api_url"`
+	CFSecurityURL       types.String `tfsdk:"cf_security_url"`
+}
+
+func (r *cfsecurityAccessTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (r *cfsecurityAccessTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Access token of the user (or client) authenticated by the provider, it is never written to state",
+		Attributes: map[string]schema.Attribute{
+			"access_token": schema.StringAttribute{
+				Description: "The bearer access token",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"authorization_header": schema.StringAttribute{
+				Description: "Value of the Authorization header for the access token (bearer <access_token>)",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "Expiration date of the access token (RFC3339)",
+				Computed:    true,
+			},
+			"cf_api_url": schema.StringAttribute{
+				Description: "Url of the cloud foundry api the token is valid for",
+				Computed:    true,
+			},
+			"cf_security_url": schema.StringAttribute{
+				Description: "Url of the cfsecurity server used by the provider",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *cfsecurityAccessTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clt, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clt
+}
+
+func (r *cfsecurityAccessTokenEphemeralResource) Open(ctx context.Context, _ ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	err := refreshTokenIfExpired(r.client, r.config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to refresh token: %s", err),
+		)
+		return
+	}
+
+	// the session stores the token prefixed by its type
+	accessToken := *r.client.GetAccessToken()
+	if len(accessToken) > len("bearer ") && strings.EqualFold(accessToken[:len("bearer ")], "bearer ") {
+		accessToken = accessToken[len("bearer "):]
+	}
+
+	claims, err := getClaimsFromToken(accessToken)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to decode access token: %s", err),
+		)
+		return
+	}
+	expiresAt := time.Unix(int64(claims.Exp), 0).UTC()
+
+	data := cfsecurityAccessTokenModel{
+		AccessToken:         types.StringValue(accessToken),
+		AuthorizationHeader: types.StringValue("bearer " + accessToken),
+		ExpiresAt:           types.StringValue(expiresAt.Format(time.RFC3339)),
+		CFApiURL:            types.StringValue(r.config.Endpoint),
+		CFSecurityURL:       types.StringValue(r.client.GetEndpoint()),
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
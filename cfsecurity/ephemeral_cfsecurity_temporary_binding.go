@@ -0,0 +1,247 @@
+package cfsecurity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3"
+	clients "github.com/cloudfoundry-community/go-cf-clients-helper/v2"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+)
+
+type cfsecurityTemporaryBindingEphemeralResource struct {
+	client  *client.Client
+	config  *clients.Config
+	session *clients.Session
+}
+
+var _ ephemeral.EphemeralResource = &cfsecurityTemporaryBindingEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &cfsecurityTemporaryBindingEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &cfsecurityTemporaryBindingEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithValidateConfig = &cfsecurityTemporaryBindingEphemeralResource{}
+
+func NewCFSecurityTemporaryBindingEphemeralResource(config *clients.Config, session *clients.Session) ephemeral.EphemeralResource {
+	return &cfsecurityTemporaryBindingEphemeralResource{
+		config:  config,
+		session: session,
+	}
+}
+
+type cfsecurityTemporaryBindingModel struct {
+	AsgID        types.String `tfsdk:"asg_id"`
+	SpaceID      types.String `tfsdk:"space_id"`
+	Lifecycle    types.String `tfsdk:"lifecycle"`
+	AlreadyBound types.Bool   `tfsdk:"already_bound"`
+}
+
+// temporaryBindingKey is the private data key holding lifecycles bound on open, only these are unbound on close
+const temporaryBindingKey = "temporary_binding"
+
+type temporaryBinding struct {
+	AsgID      string   `json:"asg_id"`
+	SpaceID    string   `json:"space_id"`
+	Lifecycles []string `json:"lifecycles"`
+}
+
+func (r *cfsecurityTemporaryBindingEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_temporary_binding"
+}
+
+func (r *cfsecurityTemporaryBindingEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Bind a security group to a space for the duration of a terraform run, it is unbound when the run ends and never written to state",
+		Attributes: map[string]schema.Attribute{
+			"asg_id": schema.StringAttribute{
+				Description: "The security group guid",
+				Required:    true,
+				Validators: []validator.String{
+					guidValidator{},
+				},
+			},
+			"space_id": schema.StringAttribute{
+				Description: "The space guid",
+				Required:    true,
+				Validators: []validator.String{
+					guidValidator{},
+				},
+			},
+			"lifecycle": schema.StringAttribute{
+				Description: "Lifecycle to bind the security group to, either running or staging, both when not set",
+				Optional:    true,
+			},
+			"already_bound": schema.BoolAttribute{
+				Description: "True if the security group was already bound to the space, it is then left bound when the run ends",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *cfsecurityTemporaryBindingEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clt, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clt
+}
+
+func (r *cfsecurityTemporaryBindingEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var configData cfsecurityTemporaryBindingModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &configData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !isValidLifecycle(configData.Lifecycle) {
+		resp.Diagnostics.AddAttributeError(path.Root("lifecycle"), "Attribute Error", "\"lifecycle\" must be either \"running\" or \"staging\".")
+	}
+}
+
+// Open bind the security group for lifecycles where it is not bound yet and keep them in private data for Close
+func (r *cfsecurityTemporaryBindingEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data cfsecurityTemporaryBindingModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := refreshTokenIfExpired(r.client, r.config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to refresh token: %s", err),
+		)
+		return
+	}
+
+	asgPaths := make(map[string][]path.Path)
+	addGUIDPath(asgPaths, data.AsgID, path.Root("asg_id"))
+	spacePaths := make(map[string][]path.Path)
+	addGUIDPath(spacePaths, data.SpaceID, path.Root("space_id"))
+	resp.Diagnostics.Append(checkSecurityGroupsExist(r.client, asgPaths)...)
+	resp.Diagnostics.Append(checkSpacesExist(r.client, spacePaths)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secGroups, err := r.client.GetSecGroups([]ccv3.Query{}, 0)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get security groups : %s", err),
+		)
+		return
+	}
+
+	lifecycles := []string{lifecycleRunning, lifecycleStaging}
+	if data.Lifecycle.ValueString() != "" {
+		lifecycles = []string{data.Lifecycle.ValueString()}
+	}
+
+	binding := temporaryBinding{
+		AsgID:      data.AsgID.ValueString(),
+		SpaceID:    data.SpaceID.ValueString(),
+		Lifecycles: make([]string, 0),
+	}
+	for _, lifecycle := range lifecycles {
+		bound := false
+		for _, secGroup := range secGroups.Resources {
+			if secGroup.GUID == binding.AsgID {
+				bound = isSecurityGroupBound(secGroup, binding.SpaceID, lifecycle)
+				break
+			}
+		}
+		if bound {
+			continue
+		}
+
+		err := bindSecurityGroupForLifecycle(r.client, binding.AsgID, binding.SpaceID, lifecycle)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to bind security group, got error: %s", err),
+			)
+			// close is not called when open fails, roll back lifecycles already bound
+			for _, boundLifecycle := range binding.Lifecycles {
+				_ = unbindSecurityGroupForLifecycle(r.client, r.session.V3(), binding.AsgID, binding.SpaceID, boundLifecycle)
+			}
+			return
+		}
+		binding.Lifecycles = append(binding.Lifecycles, lifecycle)
+	}
+
+	privateData, err := json.Marshal(binding)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to save temporary binding: %s", err),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, temporaryBindingKey, privateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.AlreadyBound = types.BoolValue(len(binding.Lifecycles) == 0)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// Close unbind the security group for lifecycles bound on open, bindings which existed before are left untouched
+func (r *cfsecurityTemporaryBindingEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	privateData, diags := req.Private.GetKey(ctx, temporaryBindingKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(privateData) == 0 {
+		return
+	}
+
+	var binding temporaryBinding
+	err := json.Unmarshal(privateData, &binding)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read temporary binding: %s", err),
+		)
+		return
+	}
+	if len(binding.Lifecycles) == 0 {
+		return
+	}
+
+	err = refreshTokenIfExpired(r.client, r.config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to refresh token: %s", err),
+		)
+		return
+	}
+
+	for _, lifecycle := range binding.Lifecycles {
+		err := unbindSecurityGroupForLifecycle(r.client, r.session.V3(), binding.AsgID, binding.SpaceID, lifecycle)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to unbind security group %s from space %s, it must be unbound manually, got error: %s", binding.AsgID, binding.SpaceID, err),
+			)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package cfsecurity
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3"
+	clients "github.com/cloudfoundry-community/go-cf-clients-helper/v2"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+	"github.com/thoas/go-funk"
+)
+
+type cfsecurityAsgListResource struct {
+	client  *client.Client
+	config  *clients.Config
+	session *clients.Session
+}
+
+var _ list.ListResource = &cfsecurityAsgListResource{}
+var _ list.ListResourceWithConfigure = &cfsecurityAsgListResource{}
+var _ list.ListResourceWithValidateConfig = &cfsecurityAsgListResource{}
+
+func NewCFSecurityAsgListResource(config *clients.Config, session *clients.Session) list.ListResource {
+	return &cfsecurityAsgListResource{
+		config:  config,
+		session: session,
+	}
+}
+
+func (r *cfsecurityAsgListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asg"
+}
+
+func (r *cfsecurityAsgListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listFilterSchema(
+		"List security groups, only those bound to spaces of org_id or to space_id when set",
+		"Only list security groups bound to spaces of this org",
+		"Only list security groups bound to this space",
+	)
+}
+
+func (r *cfsecurityAsgListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clt, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clt
+}
+
+func (r *cfsecurityAsgListResource) ValidateListResourceConfig(ctx context.Context, req list.ValidateConfigRequest, resp *list.ValidateConfigResponse) {
+	var configData listFilterModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &configData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateListFilter(configData)...)
+}
+
+func (r *cfsecurityAsgListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var data listFilterModel
+	diags := req.Config.Get(ctx, &data)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	err := refreshTokenIfExpired(r.client, r.config)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to refresh token: %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// security groups bound to spaces of the org or to the space, nil when not filtered
+	var boundAsgIDs []string
+	if data.OrgID.ValueString() != "" || data.SpaceID.ValueString() != "" {
+		secGroups, err := r.client.GetSecGroups([]ccv3.Query{}, 0)
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to get security groups : %s", err),
+			)
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+		listed, err := getListedBindings(r.client, secGroups, data.OrgID.ValueString(), data.SpaceID.ValueString(), "")
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to get spaces : %s", err),
+			)
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+		boundAsgIDs = make([]string, 0, len(listed))
+		for _, binding := range listed {
+			boundAsgIDs = append(boundAsgIDs, binding.AsgID)
+		}
+	}
+
+	secGroups, _, err := r.session.V3().GetSecurityGroups()
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get security groups : %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var include []string
+	if data.AsgName.ValueString() != "" {
+		include = []string{data.AsgName.ValueString()}
+	}
+
+	ruleType := req.ResourceSchema.GetBlocks()["rule"].(schema.SetNestedBlock).NestedObject.Type()
+	stream.Results = func(push func(list.ListResult) bool) {
+		count := int64(0)
+		for _, secGroup := range secGroups {
+			if !matchNamePatterns(secGroup.Name, include, nil) {
+				continue
+			}
+			if boundAsgIDs != nil && !funk.ContainsString(boundAsgIDs, secGroup.GUID) {
+				continue
+			}
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+			count++
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = secGroup.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, cfsecurityAsgResourceIdentityModel{
+				AsgGUID: types.StringValue(secGroup.GUID),
+			})...)
+			if req.IncludeResource {
+				rules, diags := types.SetValueFrom(ctx, ruleType, cfRulesToAsg(secGroup.Rules))
+				result.Diagnostics.Append(diags...)
+				if !diags.HasError() {
+					result.Diagnostics.Append(result.Resource.Set(ctx, &cfsecurityAsgResourceModel{
+						Id:                     types.StringValue(secGroup.GUID),
+						Name:                   types.StringValue(secGroup.Name),
+						Rule:                   rules,
+						GloballyEnabledRunning: types.BoolPointerValue(secGroup.RunningGloballyEnabled),
+						GloballyEnabledStaging: types.BoolPointerValue(secGroup.StagingGloballyEnabled),
+					})...)
+				}
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package cfsecurity
+
+import (
+	"context"
+	"fmt"
+	pathpkg "path"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3"
+	clients "github.com/cloudfoundry-community/go-cf-clients-helper/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+)
+
+type cfsecurityBindListResource struct {
+	client *client.Client
+	config *clients.Config
+}
+
+var _ list.ListResource = &cfsecurityBindListResource{}
+var _ list.ListResourceWithConfigure = &cfsecurityBindListResource{}
+var _ list.ListResourceWithValidateConfig = &cfsecurityBindListResource{}
+
+func NewCFSecurityBindListResource(config *clients.Config) list.ListResource {
+	return &cfsecurityBindListResource{
+		config: config,
+	}
+}
+
+// listFilterModel is the configuration of list resources, bindings or security groups are filtered by every attribute set
+type listFilterModel struct {
+	OrgID   types.String `tfsdk:"org_id"`
+	SpaceID types.String `tfsdk:"space_id"`
+	AsgName types.String `tfsdk:"asg_name"`
+}
+
+// listFilterSchema return schema of configuration of list resources, org_id and space_id are described by each resource
+func listFilterSchema(description, orgDescription, spaceDescription string) listschema.Schema {
+	return listschema.Schema{
+		Description: description,
+		Attributes: map[string]listschema.Attribute{
+			"org_id": listschema.StringAttribute{
+				Description: orgDescription,
+				Optional:    true,
+				Validators: []validator.String{
+					guidValidator{},
+				},
+			},
+			"space_id": listschema.StringAttribute{
+				Description: spaceDescription,
+				Optional:    true,
+				Validators: []validator.String{
+					guidValidator{},
+				},
+			},
+			"asg_name": listschema.StringAttribute{
+				Description: "Only list security groups with a name matching this pattern (shell glob syntax, e.g.: platform-egress-*)",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// validateListFilter check that asg_name is a valid pattern
+func validateListFilter(config listFilterModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !config.AsgName.IsUnknown() && !config.AsgName.IsNull() {
+		if _, err := pathpkg.Match(config.AsgName.ValueString(), ""); err != nil {
+			diags.AddAttributeError(path.Root("asg_name"), "Attribute Error", fmt.Sprintf("invalid pattern %q: %s", config.AsgName.ValueString(), err))
+		}
+	}
+	return diags
+}
+
+func (r *cfsecurityBindListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bind_asg"
+}
+
+func (r *cfsecurityBindListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listFilterSchema(
+		"List security groups bound to spaces, each binding is listed as a cfsecurity_bind_asg holding only this binding",
+		"Only list bindings to spaces of this org",
+		"Only list bindings to this space",
+	)
+}
+
+func (r *cfsecurityBindListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clt, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clt
+}
+
+func (r *cfsecurityBindListResource) ValidateListResourceConfig(ctx context.Context, req list.ValidateConfigRequest, resp *list.ValidateConfigResponse) {
+	var configData listFilterModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &configData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateListFilter(configData)...)
+}
+
+func (r *cfsecurityBindListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var data listFilterModel
+	diags := req.Config.Get(ctx, &data)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	err := refreshTokenIfExpired(r.client, r.config)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to refresh token: %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	secGroups, err := r.client.GetSecGroups([]ccv3.Query{}, 0)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get security groups : %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	listed, err := getListedBindings(r.client, secGroups, data.OrgID.ValueString(), data.SpaceID.ValueString(), data.AsgName.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get spaces : %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	bindType := req.ResourceSchema.GetBlocks()["bind"].(schema.SetNestedBlock).NestedObject.Type()
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, binding := range listed {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+			result := req.NewListResult(ctx)
+			spaceName := binding.SpaceName
+			if spaceName == "" {
+				spaceName = binding.SpaceID
+			}
+			result.DisplayName = fmt.Sprintf("%s bound to space %s", binding.AsgName, spaceName)
+
+			lifecycle := types.StringNull()
+			if binding.Lifecycle != "" {
+				lifecycle = types.StringValue(binding.Lifecycle)
+				result.DisplayName += fmt.Sprintf(" for %s", binding.Lifecycle)
+			}
+			binds := []bind{{
+				AsgID:     types.StringValue(binding.AsgID),
+				SpaceID:   types.StringValue(binding.SpaceID),
+				Lifecycle: lifecycle,
+			}}
+			result.Diagnostics.Append(result.Identity.Set(ctx, bindResourceIdentity("", false, binds))...)
+			if req.IncludeResource {
+				model, diags := newBindResourceModel(ctx, bindType, binds)
+				result.Diagnostics.Append(diags...)
+				if !diags.HasError() {
+					result.Diagnostics.Append(result.Resource.Set(ctx, &model)...)
+				}
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -14,6 +14,9 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -24,6 +27,8 @@ import (
 )
 
 var _ provider.Provider = &CFSecurityProvider{}
+var _ provider.ProviderWithEphemeralResources = &CFSecurityProvider{}
+var _ provider.ProviderWithListResources = &CFSecurityProvider{}
 
 type CFSecurityProvider struct {
 	// version is set to the provider version on release, "dev" when the
@@ -31,6 +36,16 @@ type CFSecurityProvider struct {
 	// testing.
 	version string
 	config  *clients.Config
+	session *clients.Session
+	options providerOptions
+}
+
+// providerOptions are provider settings which change the behaviour of resources
+type providerOptions struct {
+	// StrictMode turn warnings about bindings which have no effect into errors
+	StrictMode bool
+	// MaxDuration is the longest time a binding can be requested for with expires_at, 0 means no limit
+	MaxDuration time.Duration
 }
 
 type CFSecurityProviderModel struct {
@@ -42,6 +57,8 @@ type CFSecurityProviderModel struct {
 	CFClientID        types.String `tfsdk:"cf_client_id"`
 	CFClientSecret    types.String `tfsdk:"cf_client_secret"`
 	SkipSslValidation types.Bool   `tfsdk:"skip_ssl_validation"`
+	StrictMode        types.Bool   `tfsdk:"strict_mode"`
+	MaxDuration       types.String `tfsdk:"max_duration"`
 }
 
 func (m CFSecurityProviderModel) valid() (bool, CFSecurityProviderModel) {
@@ -104,6 +121,14 @@ func (p *CFSecurityProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 			"skip_ssl_validation": schema.BoolAttribute{
 				Required: true,
 			},
+			"strict_mode": schema.BoolAttribute{
+				Description: "Fail plan instead of warning when bindings have no effect (security group globally enabled, duplicated guid)",
+				Optional:    true,
+			},
+			"max_duration": schema.StringAttribute{
+				Description: "Longest duration from now allowed for expires_at of bindings (e.g.: 72h), no limit when not set",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -144,6 +169,24 @@ func (p *CFSecurityProvider) Configure(ctx context.Context, req provider.Configu
 		)
 		return
 	}
+	p.session = s
+
+	var maxDuration time.Duration
+	if data.MaxDuration.ValueString() != "" {
+		maxDuration, err = time.ParseDuration(data.MaxDuration.ValueString())
+		if err != nil || maxDuration <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_duration"),
+				"Client Error: Bad parameter",
+				fmt.Sprintf("max_duration %q must be a positive duration (e.g.: 72h)", data.MaxDuration.ValueString()),
+			)
+			return
+		}
+	}
+	p.options = providerOptions{
+		StrictMode:  data.StrictMode.ValueBool(),
+		MaxDuration: maxDuration,
+	}
 
 	uri, err := url.Parse(p.config.Endpoint)
 	if err != nil {
@@ -171,18 +214,46 @@ func (p *CFSecurityProvider) Configure(ctx context.Context, req provider.Configu
 	)
 	resp.DataSourceData = data.client
 	resp.ResourceData = data.client
+	resp.EphemeralResourceData = data.client
+	resp.ListResourceData = data.client
 }
 
 func (p *CFSecurityProvider) Resources(context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		func() resource.Resource { return NewCFSecurityEntitleAsgResource(p.config) },
-		func() resource.Resource { return NewCFSecurityBindResource(p.config) },
+		func() resource.Resource { return NewCFSecurityBindResource(p.config, p.session, p.options) },
+		func() resource.Resource { return NewCFSecurityAsgResource(p.config, p.session) },
+		func() resource.Resource { return NewCFSecurityDefaultAsgsResource(p.config, p.session) },
+		func() resource.Resource { return NewCFSecurityOrgAsgBindingResource(p.config, p.session, p.options) },
+		func() resource.Resource { return NewCFSecurityLabelAsgBindingResource(p.config, p.session, p.options) },
+	}
+}
+
+func (p *CFSecurityProvider) EphemeralResources(context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		func() ephemeral.EphemeralResource {
+			return NewCFSecurityTemporaryBindingEphemeralResource(p.config, p.session)
+		},
+		func() ephemeral.EphemeralResource { return NewCFSecurityAccessTokenEphemeralResource(p.config) },
+	}
+}
+
+func (p *CFSecurityProvider) ListResources(context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		func() list.ListResource { return NewCFSecurityBindListResource(p.config) },
+		func() list.ListResource { return NewCFSecurityAsgListResource(p.config, p.session) },
 	}
 }
 
 func (p *CFSecurityProvider) DataSources(context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		func() datasource.DataSource { return NewCFSecurityAsgDataSource(p.config) },
+		func() datasource.DataSource { return NewCFSecuritySpaceEgressDataSource(p.config, p.session) },
+		func() datasource.DataSource { return NewCFSecurityEgressCheckDataSource(p.config, p.session) },
+		func() datasource.DataSource { return NewCFSecurityCurrentUserDataSource(p.config, p.session) },
+		func() datasource.DataSource { return NewCFSecurityOrgManagersDataSource(p.config, p.session) },
+		func() datasource.DataSource { return NewCFSecurityOrgDataSource(p.config) },
+		func() datasource.DataSource { return NewCFSecuritySpaceDataSource(p.config) },
 	}
 }
 
@@ -194,22 +265,40 @@ func New(version string) func() provider.Provider {
 	}
 }
 
-func getExpiresAtFromToken(accessToken string) (time.Time, error) {
+// tokenClaims are the claims of an uaa access token used by the provider
+type tokenClaims struct {
+	Sub      string   `json:"sub"`
+	UserID   string   `json:"user_id"`
+	UserName string   `json:"user_name"`
+	Origin   string   `json:"origin"`
+	ClientID string   `json:"client_id"`
+	Scopes   []string `json:"scope"`
+	Exp      int      `json:"exp"`
+}
+
+func getClaimsFromToken(accessToken string) (tokenClaims, error) {
+	token := tokenClaims{}
+
 	tokenSplit := strings.Split(accessToken, ".")
 	if len(tokenSplit) < 3 {
-		return time.Now(), fmt.Errorf("not a jwt")
+		return token, fmt.Errorf("not a jwt")
 	}
 
-	decodeToken, err := base64.RawStdEncoding.DecodeString(tokenSplit[1])
+	// jwt segments are base64url encoded (RFC 7519), padding is tolerated
+	decodeToken, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(tokenSplit[1], "="))
 	if err != nil {
-		return time.Now(), err
+		return token, err
 	}
 
-	token := struct {
-		Exp int `json:"exp"`
-	}{}
-
 	err = json.Unmarshal(decodeToken, &token)
+	if err != nil {
+		return token, err
+	}
+	return token, nil
+}
+
+func getExpiresAtFromToken(accessToken string) (time.Time, error) {
+	token, err := getClaimsFromToken(accessToken)
 	if err != nil {
 		return time.Now(), err
 	}
@@ -0,0 +1,45 @@
+package cfsecurity
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestGetClaimsFromToken(t *testing.T) {
+	// "~" in user name gives a "-" in base64url, which base64 std does not decode
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"user_name":"~admin","user_id":"a5c1d8b2","exp":1700000000}`))
+	if !strings.ContainsAny(payload, "-_") {
+		t.Fatalf("payload %s must contain base64url specific characters", payload)
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		userName string
+		wantErr  bool
+	}{
+		{name: "base64url payload", token: "header." + payload + ".signature", userName: "~admin"},
+		{name: "bearer prefix", token: "bearer header." + payload + ".signature", userName: "~admin"},
+		{name: "padded payload", token: "header." + base64.URLEncoding.EncodeToString([]byte(`{"user_name":"admin"}`)) + ".signature", userName: "admin"},
+		{name: "not a jwt", token: "header.payload", wantErr: true},
+		{name: "invalid payload", token: "header.!!.signature", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := getClaimsFromToken(tt.token)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", claims)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if claims.UserName != tt.userName {
+				t.Errorf("got user name %q, want %q", claims.UserName, tt.userName)
+			}
+		})
+	}
+}
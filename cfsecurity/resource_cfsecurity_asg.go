@@ -0,0 +1,464 @@
+package cfsecurity
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/v8/resources"
+	clients "github.com/cloudfoundry-community/go-cf-clients-helper/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+)
+
+type cfsecurityAsgResource struct {
+	client  *client.Client
+	config  *clients.Config
+	session *clients.Session
+}
+
+var _ resource.Resource = &cfsecurityAsgResource{}
+var _ resource.ResourceWithConfigure = &cfsecurityAsgResource{}
+var _ resource.ResourceWithImportState = &cfsecurityAsgResource{}
+var _ resource.ResourceWithValidateConfig = &cfsecurityAsgResource{}
+var _ resource.ResourceWithIdentity = &cfsecurityAsgResource{}
+
+func NewCFSecurityAsgResource(config *clients.Config, session *clients.Session) resource.Resource {
+	return &cfsecurityAsgResource{
+		config:  config,
+		session: session,
+	}
+}
+
+type cfsecurityAsgResourceModel struct {
+	Id                     types.String `tfsdk:"id"`
+	Name                   types.String `tfsdk:"name"`
+	Rule                   types.Set    `tfsdk:"rule"`
+	GloballyEnabledRunning types.Bool   `tfsdk:"globally_enabled_running"`
+	GloballyEnabledStaging types.Bool   `tfsdk:"globally_enabled_staging"`
+}
+
+type cfsecurityAsgResourceIdentityModel struct {
+	AsgGUID types.String `tfsdk:"asg_guid"`
+}
+
+type asgRule struct {
+	Protocol    types.String `tfsdk:"protocol"`
+	Destination types.String `tfsdk:"destination"`
+	Ports       types.String `tfsdk:"ports"`
+	Type        types.Int64  `tfsdk:"type"`
+	Code        types.Int64  `tfsdk:"code"`
+	Description types.String `tfsdk:"description"`
+	Log         types.Bool   `tfsdk:"log"`
+}
+
+func (r *cfsecurityAsgResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asg"
+}
+
+func (r *cfsecurityAsgResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"asg_guid": identityschema.StringAttribute{
+				Description:       "The security group guid",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *cfsecurityAsgResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clt, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clt
+}
+
+func (r *cfsecurityAsgResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manage a security group, only usable by an admin",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The security group name",
+				Required:    true,
+			},
+			"globally_enabled_running": schema.BoolAttribute{
+				Description: "Enable the security group for running apps of all spaces, left untouched when not set",
+				Optional:    true,
+			},
+			"globally_enabled_staging": schema.BoolAttribute{
+				Description: "Enable the security group for staging apps of all spaces, left untouched when not set",
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"rule": schema.SetNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"protocol": schema.StringAttribute{
+							Description: "The protocol: tcp, udp, icmp, icmpv6 or all",
+							Required:    true,
+						},
+						"destination": schema.StringAttribute{
+							Description: "The destination: an ip, a cidr or an ip range",
+							Required:    true,
+						},
+						"ports": schema.StringAttribute{
+							Description: "The ports for tcp and udp (e.g.: 443, 80,8080 or 8000-8100)",
+							Optional:    true,
+						},
+						"type": schema.Int64Attribute{
+							Description: "The icmp type, -1 for all types",
+							Optional:    true,
+						},
+						"code": schema.Int64Attribute{
+							Description: "The icmp code, -1 for all codes",
+							Optional:    true,
+						},
+						"description": schema.StringAttribute{
+							Optional: true,
+						},
+						"log": schema.BoolAttribute{
+							Description: "Log the connections made with the rule (tcp only)",
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *cfsecurityAsgResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan cfsecurityAsgResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkCurrentUserIsAdmin(r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var rules []asgRule
+	resp.Diagnostics.Append(plan.Rule.ElementsAs(ctx, &rules, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secGroup, _, err := r.session.V3().CreateSecurityGroup(resources.SecurityGroup{
+		Name:                   plan.Name.ValueString(),
+		Rules:                  asgRulesToCF(rules),
+		RunningGloballyEnabled: plan.GloballyEnabledRunning.ValueBoolPointer(),
+		StagingGloballyEnabled: plan.GloballyEnabledStaging.ValueBoolPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create security group, got error: %s", err),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(secGroup.GUID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setAsgIdentity(ctx, resp.Identity, plan.Id)...)
+}
+
+func (r *cfsecurityAsgResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state cfsecurityAsgResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secGroups, _, err := r.session.V3().GetSecurityGroups(ccv3.Query{Key: ccv3.GUIDFilter, Values: []string{state.Id.ValueString()}})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get security group : %s", err),
+		)
+		return
+	}
+	if len(secGroups) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	secGroup := secGroups[0]
+
+	var stateRules []asgRule
+	state.Rule.ElementsAs(ctx, &stateRules, false)
+	serverRules := cfRulesToAsg(secGroup.Rules)
+
+	// rules are only replaced when they differ, this keep the way rules are written by the user
+	if !asgRulesEqual(stateRules, serverRules) {
+		ruleType := req.State.Schema.GetBlocks()["rule"].(schema.SetNestedBlock).NestedObject.Type()
+		rules, aErr := types.SetValueFrom(ctx, ruleType, serverRules)
+		if aErr.HasError() {
+			resp.Diagnostics.Append(aErr...)
+			return
+		}
+		state.Rule = rules
+	}
+
+	state.Name = types.StringValue(secGroup.Name)
+	if !state.GloballyEnabledRunning.IsNull() && secGroup.RunningGloballyEnabled != nil {
+		state.GloballyEnabledRunning = types.BoolValue(*secGroup.RunningGloballyEnabled)
+	}
+	if !state.GloballyEnabledStaging.IsNull() && secGroup.StagingGloballyEnabled != nil {
+		state.GloballyEnabledStaging = types.BoolValue(*secGroup.StagingGloballyEnabled)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setAsgIdentity(ctx, resp.Identity, state.Id)...)
+}
+
+func (r *cfsecurityAsgResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state cfsecurityAsgResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkCurrentUserIsAdmin(r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var rules []asgRule
+	resp.Diagnostics.Append(plan.Rule.ElementsAs(ctx, &rules, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// renaming keeps the guid, bindings and global enablement of the security group
+	_, err := updateSecurityGroup(r.session.V3(), state.Id.ValueString(), securityGroupUpdate{
+		Name:  plan.Name.ValueString(),
+		Rules: asgRulesToCF(rules),
+		GloballyEnabled: securityGroupGloballyEnabled{
+			Running: plan.GloballyEnabledRunning.ValueBoolPointer(),
+			Staging: plan.GloballyEnabledStaging.ValueBoolPointer(),
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update security group, got error: %s", err),
+		)
+		return
+	}
+
+	plan.Id = state.Id
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setAsgIdentity(ctx, resp.Identity, plan.Id)...)
+}
+
+func (r *cfsecurityAsgResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state cfsecurityAsgResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkCurrentUserIsAdmin(r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jobURL, _, err := r.session.V3().DeleteSecurityGroup(state.Id.ValueString())
+	if err == nil {
+		_, err = r.session.V3().PollJob(jobURL)
+	}
+	if err != nil && !isCCNotFoundErr(err) {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete security group, got error: %s", err),
+		)
+		return
+	}
+}
+
+func (r *cfsecurityAsgResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("asg_guid"), req, resp)
+}
+
+// setAsgIdentity set identity of the resource from the security group guid, nothing is done when terraform does not support identity
+func setAsgIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, asgID types.String) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, cfsecurityAsgResourceIdentityModel{AsgGUID: asgID})
+}
+
+func (r *cfsecurityAsgResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var configData cfsecurityAsgResourceModel
+
+	// Read Terraform configuration from the request into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &configData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if configData.Rule.IsUnknown() {
+		return
+	}
+
+	var rules []asgRule
+	configData.Rule.ElementsAs(ctx, &rules, false)
+	for _, rule := range rules {
+		if rule.Protocol.IsUnknown() {
+			continue
+		}
+		switch strings.ToLower(rule.Protocol.ValueString()) {
+		case protocolTCP, protocolUDP:
+			if rule.Ports.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root("rule"), "Attribute Error", "\"ports\" must be provided for tcp and udp rules.")
+			}
+			if !rule.Type.IsNull() || !rule.Code.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root("rule"), "Attribute Error", "\"type\" and \"code\" can only be set on icmp rules.")
+			}
+		case protocolICMP, protocolICMPv6:
+			if rule.Type.IsNull() || rule.Code.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root("rule"), "Attribute Error", "\"type\" and \"code\" must be provided for icmp rules.")
+			}
+			if !rule.Ports.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root("rule"), "Attribute Error", "\"ports\" can only be set on tcp and udp rules.")
+			}
+		case protocolAll:
+			if !rule.Ports.IsNull() || !rule.Type.IsNull() || !rule.Code.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root("rule"), "Attribute Error", "\"ports\", \"type\" and \"code\" can't be set on rules with protocol all.")
+			}
+		default:
+			resp.Diagnostics.AddAttributeError(path.Root("rule"), "Attribute Error", "\"protocol\" must be one of tcp, udp, icmp, icmpv6 or all.")
+		}
+	}
+}
+
+func asgRulesToCF(rules []asgRule) []resources.Rule {
+	cfRules := make([]resources.Rule, 0, len(rules))
+	for _, rule := range rules {
+		cfRule := resources.Rule{
+			Protocol:    strings.ToLower(rule.Protocol.ValueString()),
+			Destination: rule.Destination.ValueString(),
+			Ports:       rule.Ports.ValueStringPointer(),
+			Description: rule.Description.ValueStringPointer(),
+			Log:         rule.Log.ValueBoolPointer(),
+		}
+		if !rule.Type.IsNull() {
+			ruleType := int(rule.Type.ValueInt64())
+			cfRule.Type = &ruleType
+		}
+		if !rule.Code.IsNull() {
+			ruleCode := int(rule.Code.ValueInt64())
+			cfRule.Code = &ruleCode
+		}
+		cfRules = append(cfRules, cfRule)
+	}
+	return cfRules
+}
+
+func cfRulesToAsg(cfRules []resources.Rule) []asgRule {
+	rules := make([]asgRule, 0, len(cfRules))
+	for _, cfRule := range cfRules {
+		rule := asgRule{
+			Protocol:    types.StringValue(cfRule.Protocol),
+			Destination: types.StringValue(cfRule.Destination),
+			Ports:       types.StringPointerValue(cfRule.Ports),
+			Type:        types.Int64Null(),
+			Code:        types.Int64Null(),
+			Description: types.StringPointerValue(cfRule.Description),
+			Log:         types.BoolPointerValue(cfRule.Log),
+		}
+		if cfRule.Type != nil {
+			rule.Type = types.Int64Value(int64(*cfRule.Type))
+		}
+		if cfRule.Code != nil {
+			rule.Code = types.Int64Value(int64(*cfRule.Code))
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// asgRuleKey give a comparable representation of a rule where unset values are replaced by cloud foundry defaults
+func asgRuleKey(rule asgRule) string {
+	ruleType, ruleCode := "", ""
+	if !rule.Type.IsNull() {
+		ruleType = fmt.Sprintf("%d", rule.Type.ValueInt64())
+	}
+	if !rule.Code.IsNull() {
+		ruleCode = fmt.Sprintf("%d", rule.Code.ValueInt64())
+	}
+	return strings.Join([]string{
+		strings.ToLower(rule.Protocol.ValueString()),
+		strings.ReplaceAll(rule.Destination.ValueString(), " ", ""),
+		strings.ReplaceAll(rule.Ports.ValueString(), " ", ""),
+		ruleType,
+		ruleCode,
+		rule.Description.ValueString(),
+		fmt.Sprintf("%t", rule.Log.ValueBool()),
+	}, "|")
+}
+
+// asgRulesEqual compare rules regardless of their order
+func asgRulesEqual(rules []asgRule, others []asgRule) bool {
+	if len(rules) != len(others) {
+		return false
+	}
+	keys := make([]string, len(rules))
+	otherKeys := make([]string, len(others))
+	for i := range rules {
+		keys[i] = asgRuleKey(rules[i])
+		otherKeys[i] = asgRuleKey(others[i])
+	}
+	sort.Strings(keys)
+	sort.Strings(otherKeys)
+	for i := range keys {
+		if keys[i] != otherKeys[i] {
+			return false
+		}
+	}
+	return true
+}
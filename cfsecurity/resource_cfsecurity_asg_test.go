@@ -0,0 +1,95 @@
+package cfsecurity
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAsgRuleKey(t *testing.T) {
+	rule := asgRule{
+		Protocol:    types.StringValue("tcp"),
+		Destination: types.StringValue("10.0.0.0/8"),
+		Ports:       types.StringValue("443,8080-8090"),
+		Type:        types.Int64Null(),
+		Code:        types.Int64Null(),
+		Description: types.StringValue("internal"),
+		Log:         types.BoolNull(),
+	}
+
+	tests := []struct {
+		name  string
+		other func(asgRule) asgRule
+		equal bool
+	}{
+		{name: "same rule", other: func(r asgRule) asgRule { return r }, equal: true},
+		{name: "protocol case", other: func(r asgRule) asgRule { r.Protocol = types.StringValue("TCP"); return r }, equal: true},
+		{name: "spaces in ports", other: func(r asgRule) asgRule { r.Ports = types.StringValue("443, 8080-8090"); return r }, equal: true},
+		{name: "spaces in destination", other: func(r asgRule) asgRule { r.Destination = types.StringValue(" 10.0.0.0/8"); return r }, equal: true},
+		{name: "log false is the default", other: func(r asgRule) asgRule { r.Log = types.BoolValue(false); return r }, equal: true},
+		{name: "other ports", other: func(r asgRule) asgRule { r.Ports = types.StringValue("443"); return r }, equal: false},
+		{name: "no ports", other: func(r asgRule) asgRule { r.Ports = types.StringNull(); return r }, equal: false},
+		{name: "other description", other: func(r asgRule) asgRule { r.Description = types.StringValue("external"); return r }, equal: false},
+		{name: "no description", other: func(r asgRule) asgRule { r.Description = types.StringNull(); return r }, equal: false},
+		{name: "log enabled", other: func(r asgRule) asgRule { r.Log = types.BoolValue(true); return r }, equal: false},
+		{name: "icmp type", other: func(r asgRule) asgRule { r.Type = types.Int64Value(0); return r }, equal: false},
+		{name: "icmp code", other: func(r asgRule) asgRule { r.Code = types.Int64Value(0); return r }, equal: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := tt.other(rule)
+			if got := asgRuleKey(rule) == asgRuleKey(other); got != tt.equal {
+				t.Errorf("asgRuleKey(%+v) == asgRuleKey(%+v) is %v, want %v", rule, other, got, tt.equal)
+			}
+		})
+	}
+}
+
+func TestAsgRulesEqual(t *testing.T) {
+	newRule := func(protocol, destination, ports, description string) asgRule {
+		rule := asgRule{
+			Protocol:    types.StringValue(protocol),
+			Destination: types.StringValue(destination),
+			Ports:       types.StringNull(),
+			Type:        types.Int64Null(),
+			Code:        types.Int64Null(),
+			Description: types.StringNull(),
+			Log:         types.BoolNull(),
+		}
+		if ports != "" {
+			rule.Ports = types.StringValue(ports)
+		}
+		if description != "" {
+			rule.Description = types.StringValue(description)
+		}
+		return rule
+	}
+	https := newRule("tcp", "10.0.0.0/8", "443", "https")
+	dns := newRule("udp", "10.0.0.2", "53", "")
+	icmp := newRule("icmp", "0.0.0.0/0", "", "")
+	icmp.Type, icmp.Code = types.Int64Value(-1), types.Int64Value(-1)
+
+	tests := []struct {
+		name   string
+		rules  []asgRule
+		others []asgRule
+		want   bool
+	}{
+		{name: "no rules", want: true},
+		{name: "same rules", rules: []asgRule{https, dns, icmp}, others: []asgRule{https, dns, icmp}, want: true},
+		{name: "reordered rules", rules: []asgRule{https, dns, icmp}, others: []asgRule{icmp, https, dns}, want: true},
+		{name: "rule missing", rules: []asgRule{https, dns}, others: []asgRule{https}, want: false},
+		{name: "rule replaced", rules: []asgRule{https, dns}, others: []asgRule{https, icmp}, want: false},
+		{name: "duplicated rule", rules: []asgRule{https, dns}, others: []asgRule{https, https}, want: false},
+		{name: "other ports", rules: []asgRule{https, dns}, others: []asgRule{dns, newRule("tcp", "10.0.0.0/8", "8443", "https")}, want: false},
+		{name: "other description", rules: []asgRule{https, dns}, others: []asgRule{dns, newRule("tcp", "10.0.0.0/8", "443", "")}, want: false},
+		{name: "same fields reordered", rules: []asgRule{https, dns}, others: []asgRule{dns, newRule("TCP", "10.0.0.0/8", "443", "https")}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := asgRulesEqual(tt.rules, tt.others); got != tt.want {
+				t.Errorf("asgRulesEqual() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
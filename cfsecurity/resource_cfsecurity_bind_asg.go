@@ -2,51 +2,138 @@ package cfsecurity
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/v8/resources"
 
 	clients "github.com/cloudfoundry-community/go-cf-clients-helper/v2"
 	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
 	"github.com/thoas/go-funk"
 )
 
 type cfsecurityBindResource struct {
-	client *client.Client
-	config *clients.Config
+	client  *client.Client
+	config  *clients.Config
+	session *clients.Session
+	options providerOptions
 }
 
 var _ resource.Resource = &cfsecurityBindResource{}
 var _ resource.ResourceWithConfigure = &cfsecurityBindResource{}
 var _ resource.ResourceWithImportState = &cfsecurityBindResource{}
 var _ resource.ResourceWithValidateConfig = &cfsecurityBindResource{}
+var _ resource.ResourceWithMoveState = &cfsecurityBindResource{}
+var _ resource.ResourceWithUpgradeState = &cfsecurityBindResource{}
+var _ resource.ResourceWithModifyPlan = &cfsecurityBindResource{}
+var _ resource.ResourceWithIdentity = &cfsecurityBindResource{}
 
-func NewCFSecurityBindResource(config *clients.Config) resource.Resource {
+func NewCFSecurityBindResource(config *clients.Config, session *clients.Session, options providerOptions) resource.Resource {
 	return &cfsecurityBindResource{
-		config: config,
+		config:  config,
+		session: session,
+		options: options,
 	}
 }
 
 type cfsecurityBindResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	Bind              types.Set    `tfsdk:"bind"`
+	Force             types.Bool   `tfsdk:"force"`
+	ReportUnmanaged   types.Bool   `tfsdk:"report_unmanaged"`
+	UnmanagedBindings types.Set    `tfsdk:"unmanaged_bindings"`
+	RemoveWhenGone    types.Bool   `tfsdk:"remove_when_gone"`
+	OnExisting        types.String `tfsdk:"on_existing"`
+	AllowDisruption   types.Bool   `tfsdk:"allow_disruption"`
+	DisruptedApps     types.Set    `tfsdk:"disrupted_apps"`
+	ExpiresAt         types.String `tfsdk:"expires_at"`
+	Expired           types.Bool   `tfsdk:"expired"`
+}
+
+// cfsecurityBindResourceModelV0 is the model of schema version 0
+type cfsecurityBindResourceModelV0 struct {
 	Id    types.String `tfsdk:"id"`
 	Bind  types.Set    `tfsdk:"bind"`
 	Force types.Bool   `tfsdk:"force"`
 }
 
+// cfsecurityBindResourceIdentityModel identify the resource, see bindResourceIdentity
+type cfsecurityBindResourceIdentityModel struct {
+	Id        types.String `tfsdk:"id"`
+	AsgGUID   types.String `tfsdk:"asg_guid"`
+	SpaceGUID types.String `tfsdk:"space_guid"`
+	Lifecycle types.String `tfsdk:"lifecycle"`
+}
+
 type bind struct {
+	AsgID     types.String `tfsdk:"asg_id"`
+	SpaceID   types.String `tfsdk:"space_id"`
+	Lifecycle types.String `tfsdk:"lifecycle"`
+}
+
+// bindV0 is a binding of schema version 0
+type bindV0 struct {
 	AsgID   types.String `tfsdk:"asg_id"`
 	SpaceID types.String `tfsdk:"space_id"`
 }
 
+type unmanagedBinding struct {
+	AsgID   types.String `tfsdk:"asg_id"`
+	AsgName types.String `tfsdk:"asg_name"`
+	SpaceID types.String `tfsdk:"space_id"`
+}
+
+var unmanagedBindingType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"asg_id":   types.StringType,
+		"asg_name": types.StringType,
+		"space_id": types.StringType,
+	},
+}
+
 func (r *cfsecurityBindResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_bind_asg"
+	// identity changes with force and with the number of bindings, see bindResourceIdentity
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+func (r *cfsecurityBindResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "Id of a resource with several bindings and force not set, it can not be imported by identity",
+				OptionalForImport: true,
+			},
+			"asg_guid": identityschema.StringAttribute{
+				Description:       "Guid of the security group of the binding, not set when force is true",
+				OptionalForImport: true,
+			},
+			"space_guid": identityschema.StringAttribute{
+				Description:       "Guid of the space of the binding, or of the space where all security groups are bound when force is true",
+				RequiredForImport: true,
+			},
+			"lifecycle": identityschema.StringAttribute{
+				Description:       "Lifecycle of the binding, running or staging, both when not set",
+				OptionalForImport: true,
+			},
+		},
+	}
 }
 
 // Configure enables provider-level data or clients to be set in the
@@ -70,8 +157,82 @@ func (r *cfsecurityBindResource) Configure(_ context.Context, req resource.Confi
 	r.client = clt
 }
 
+const (
+	onExistingAdopt = "adopt"
+	onExistingError = "error"
+	onExistingSkip  = "skip"
+)
+
+// skippedBindingsKey is the private state key holding bindings which existed before and must not be unbound
+const skippedBindingsKey = "skipped_bindings"
+
+// unboundOnExpiryKey is the private state key telling that bindings have been unbound by the last apply because they expired
+const unboundOnExpiryKey = "unbound_on_expiry"
+
+// bindAsgSchemaVersion is the current schema version of cfsecurity_bind_asg, it must be increased on each change
+// which can not be read from previous states (attributes renamed or retyped, values converted) and an upgrader
+// from every previous version added in UpgradeState, new attributes are simply null in previous states:
+//   - 0: id is a random uuid
+//   - 1: id is derived from bindings, see bindResourceID
+const bindAsgSchemaVersion = 1
+
 func (r *cfsecurityBindResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = schema.Schema{
+	resp.Schema = bindAsgSchemaV0()
+	resp.Schema.Version = bindAsgSchemaVersion
+	bindBlock := resp.Schema.Blocks["bind"].(schema.SetNestedBlock)
+	for _, name := range []string{"asg_id", "space_id"} {
+		guidAttribute := bindBlock.NestedObject.Attributes[name].(schema.StringAttribute)
+		guidAttribute.Validators = []validator.String{
+			guidValidator{},
+		}
+		bindBlock.NestedObject.Attributes[name] = guidAttribute
+	}
+	bindBlock.NestedObject.Attributes["lifecycle"] = schema.StringAttribute{
+		Description: "Lifecycle to bind the security group to, either running or staging (default: both)",
+		Optional:    true,
+	}
+	resp.Schema.Blocks["bind"] = bindBlock
+	resp.Schema.Attributes["report_unmanaged"] = schema.BoolAttribute{
+		Description: "Report with warnings and in unmanaged_bindings security groups bound to spaces of bindings by another way (ignored when force is true)",
+		Optional:    true,
+	}
+	resp.Schema.Attributes["on_existing"] = schema.StringAttribute{
+		Description: "What to do when a binding already exists on creation: adopt it (default, it is unbound on destroy), error or skip it (it is never unbound)",
+		Optional:    true,
+	}
+	resp.Schema.Attributes["allow_disruption"] = allowDisruptionAttribute()
+	resp.Schema.Attributes["disrupted_apps"] = disruptedAppsAttribute()
+	resp.Schema.Attributes["expires_at"] = expiresAtAttribute()
+	resp.Schema.Attributes["expired"] = expiredAttribute()
+	resp.Schema.Attributes["remove_when_gone"] = schema.BoolAttribute{
+		Description: "Remove the resource from state when every security group or space of bindings has been deleted",
+		Optional:    true,
+	}
+	resp.Schema.Attributes["unmanaged_bindings"] = schema.SetNestedAttribute{
+		Description: "Security groups bound to spaces of bindings which are not in bindings, only set when report_unmanaged is true",
+		Computed:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"asg_id": schema.StringAttribute{
+					Description: "The security group guid",
+					Computed:    true,
+				},
+				"asg_name": schema.StringAttribute{
+					Description: "The security group name",
+					Computed:    true,
+				},
+				"space_id": schema.StringAttribute{
+					Description: "The space guid",
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+// bindAsgSchemaV0 return schema of cfsecurity_bind_asg at version 0, it must not be changed as it is used to read states at version 0
+func bindAsgSchemaV0() schema.Schema {
+	return schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"force": schema.BoolAttribute{
 				Optional: true,
@@ -102,6 +263,75 @@ func (r *cfsecurityBindResource) Schema(_ context.Context, _ resource.SchemaRequ
 	}
 }
 
+// UpgradeState migrate states written by previous versions of the provider to the current schema version
+func (r *cfsecurityBindResource) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	schemaV0 := bindAsgSchemaV0()
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &schemaV0,
+			StateUpgrader: upgradeBindAsgStateV0,
+		},
+	}
+}
+
+// upgradeBindAsgStateV0 replace random uuid of version 0 by an id derived from bindings
+func upgradeBindAsgStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var priorState cfsecurityBindResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var bindsV0 []bindV0
+	priorState.Bind.ElementsAs(ctx, &bindsV0, false)
+	binds := make([]bind, 0, len(bindsV0))
+	for _, aBind := range bindsV0 {
+		binds = append(binds, bind{
+			AsgID:     aBind.AsgID,
+			SpaceID:   aBind.SpaceID,
+			Lifecycle: types.StringNull(),
+		})
+	}
+	id, err := bindResourceID(binds)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade State",
+			fmt.Sprintf("Unable to compute id: %s", err),
+		)
+		return
+	}
+	bindSet, diags := types.SetValueFrom(ctx, resp.State.Schema.GetBlocks()["bind"].(schema.SetNestedBlock).NestedObject.Type(), binds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &cfsecurityBindResourceModel{
+		Id:                types.StringValue(id),
+		Bind:              bindSet,
+		Force:             priorState.Force,
+		ReportUnmanaged:   types.BoolNull(),
+		UnmanagedBindings: types.SetNull(unmanagedBindingType),
+		RemoveWhenGone:    types.BoolNull(),
+		OnExisting:        types.StringNull(),
+		AllowDisruption:   types.BoolNull(),
+		DisruptedApps:     types.SetValueMust(disruptedAppType, []attr.Value{}),
+		ExpiresAt:         types.StringNull(),
+		Expired:           types.BoolValue(false),
+	})...)
+}
+
+// bindResourceID return an id derived from bindings given at creation, the same bindings always give the same id
+func bindResourceID(binds []bind) (string, error) {
+	keys := make([]string, 0, len(binds))
+	for _, aBind := range binds {
+		keys = append(keys, bindKey(aBind))
+	}
+	sort.Strings(keys)
+	sum := sha256.Sum256([]byte(strings.Join(keys, ",")))
+	return uuid.FormatUUID(sum[:16])
+}
+
 func (r *cfsecurityBindResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan cfsecurityBindResourceModel
 
@@ -121,33 +351,42 @@ func (r *cfsecurityBindResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	id, err := uuid.GenerateUUID()
+	var binds []bind
+	resp.Diagnostics.Append(plan.Bind.ElementsAs(ctx, &binds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := bindResourceID(binds)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
-			fmt.Sprintf("Unable to generate uuid: %s", err),
+			fmt.Sprintf("Unable to compute id: %s", err),
 		)
 		return
 	}
 
-	var binds []bind
-	resp.Diagnostics.Append(plan.Bind.ElementsAs(ctx, &binds, false)...)
+	plan.Expired = appliedExpired(plan.Expired, plan.ExpiresAt)
+	skipped, diags := r.bindWithExisting(activeBinds(binds, plan.Expired), plan.OnExisting.ValueString())
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(setSkippedBindings(ctx, resp.Private, skipped)...)
+	resp.Diagnostics.Append(setUnboundOnExpiry(ctx, resp.Private, plan.Expired)...)
 
-	for _, bind := range binds {
-		err = r.client.BindSecurityGroup(bind.AsgID.ValueString(), bind.SpaceID.ValueString(), r.client.GetEndpoint())
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Client Error",
-				fmt.Sprintf("Unable to bind security group, got error: %s", err),
-			)
-			return
-		}
+	disruptedApps, aErr := appliedDisruptedApps(ctx, plan.DisruptedApps, nil)
+	resp.Diagnostics.Append(aErr...)
+	plan.DisruptedApps = disruptedApps
+
+	resp.Diagnostics.Append(r.refreshUnmanagedBindings(ctx, &plan, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+
 	plan.Id = types.StringValue(id)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setBindIdentity(ctx, resp.Identity, plan, binds)...)
 }
 
 func (r *cfsecurityBindResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -176,6 +415,10 @@ func (r *cfsecurityBindResource) Read(ctx context.Context, req resource.ReadRequ
 
 	var secGroupsTf []bind
 	state.Bind.ElementsAs(ctx, &secGroupsTf, false)
+	resp.Diagnostics.Append(checkIdentity(ctx, req.Identity, bindResourceIdentity(state.Id.ValueString(), state.Force.ValueBool(), secGroupsTf))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	userIsAdmin, _ := r.client.CurrentUserIsAdmin()
 	// check if force and if user is not an admin
@@ -188,28 +431,7 @@ func (r *cfsecurityBindResource) Read(ctx context.Context, req resource.ReadRequ
 			)
 			return
 		}
-		var tfSpaceGUID = secGroupsTf[0].SpaceID.ValueString()
-
-		finalBinds := make([]bind, 0)
-		for i, secGroup := range secGroups.Resources {
-			secGroupSpaceBindings := make([]string, 0)
-			for _, space := range secGroups.Resources[i].Relationships.Running_Spaces.Data {
-				if space.GUID == tfSpaceGUID && !funk.ContainsString(secGroupSpaceBindings, space.GUID) {
-					secGroupSpaceBindings = append(secGroupSpaceBindings, space.GUID)
-				}
-			}
-			for _, space := range secGroups.Resources[i].Relationships.Staging_Spaces.Data {
-				if space.GUID == tfSpaceGUID && !funk.ContainsString(secGroupSpaceBindings, space.GUID) {
-					secGroupSpaceBindings = append(secGroupSpaceBindings, space.GUID)
-				}
-			}
-			for _, spaceGUID := range secGroupSpaceBindings {
-				finalBinds = append(finalBinds, bind{
-					AsgID:   types.StringValue(secGroup.GUID),
-					SpaceID: types.StringValue(spaceGUID),
-				})
-			}
-		}
+		finalBinds := spaceBinds(secGroups.Resources, secGroupsTf[0].SpaceID.ValueString())
 
 		bindType := req.State.Schema.GetBlocks()["bind"].(schema.SetNestedBlock).NestedObject.Type()
 		binds, aErr := types.SetValueFrom(ctx, bindType, finalBinds)
@@ -218,9 +440,52 @@ func (r *cfsecurityBindResource) Read(ctx context.Context, req resource.ReadRequ
 			return
 		}
 		state.Bind = binds
+		state.UnmanagedBindings = types.SetNull(unmanagedBindingType)
 
 		// Save updated data into Terraform state
 		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		resp.Diagnostics.Append(setBindIdentity(ctx, resp.Identity, state, finalBinds)...)
+		return
+	}
+
+	// bindings of deleted security groups or spaces are removed from state with a warning
+	vanishedAsgIDs, vanishedSpaceIDs, err := getVanishedBindTargets(r.client, r.session.V3(), secGroupsTf, &secGroups)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to check security groups and spaces of bindings: %s", err),
+		)
+		return
+	}
+	for _, asgID := range vanishedAsgIDs {
+		resp.Diagnostics.AddWarning(
+			"Security Group Deleted",
+			fmt.Sprintf("Security group %s does not exist anymore, its bindings are removed from %s", asgID, state.Id.ValueString()),
+		)
+	}
+	for _, spaceID := range vanishedSpaceIDs {
+		resp.Diagnostics.AddWarning(
+			"Space Deleted",
+			fmt.Sprintf("Space %s does not exist anymore, its bindings are removed from %s", spaceID, state.Id.ValueString()),
+		)
+	}
+	unbound, diags := getUnboundOnExpiry(ctx, req.Private, state.Expired)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// expired is refreshed from expires_at, bindings still in place are unbound by next apply
+	state.Expired = types.BoolValue(isExpired(state.ExpiresAt))
+	if state.Expired.ValueBool() && !unbound.ValueBool() {
+		addExpiredWarning(&resp.Diagnostics, state.Id.ValueString(), state.ExpiresAt)
+	}
+
+	allGone := len(secGroupsTf) > 0 && !isInSlice(secGroupsTf, func(object interface{}) bool {
+		aBind := object.(bind)
+		return !funk.ContainsString(vanishedAsgIDs, aBind.AsgID.ValueString()) && !funk.ContainsString(vanishedSpaceIDs, aBind.SpaceID.ValueString())
+	})
+	if allGone && state.RemoveWhenGone.ValueBool() {
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
@@ -232,6 +497,13 @@ func (r *cfsecurityBindResource) Read(ctx context.Context, req resource.ReadRequ
 		if asgIDTf != secGroup.GUID {
 			return false
 		}
+		// expired bindings have been unbound on purpose, they are only dropped when their space is deleted
+		if unbound.ValueBool() {
+			return !funk.ContainsString(vanishedSpaceIDs, spaceIDTf)
+		}
+		if secGroupTf.Lifecycle.ValueString() != "" {
+			return isSecurityGroupBound(secGroup, spaceIDTf, secGroupTf.Lifecycle.ValueString())
+		}
 		spaces, _ := r.client.GetSecGroupSpaces(&secGroup)
 		return isInSlice(spaces.Resources, func(object interface{}) bool {
 			space := object.(client.Space)
@@ -247,8 +519,28 @@ func (r *cfsecurityBindResource) Read(ctx context.Context, req resource.ReadRequ
 	}
 	state.Bind = binds
 
+	resp.Diagnostics.Append(r.refreshUnmanagedBindings(ctx, &state, &secGroups)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var unmanaged []unmanagedBinding
+	state.UnmanagedBindings.ElementsAs(ctx, &unmanaged, false)
+	if len(unmanaged) > 0 {
+		details := make([]string, 0, len(unmanaged))
+		for _, binding := range unmanaged {
+			details = append(details, fmt.Sprintf("  - %s (%s) on space %s", binding.AsgName.ValueString(), binding.AsgID.ValueString(), binding.SpaceID.ValueString()))
+		}
+		resp.Diagnostics.AddWarning(
+			"Unmanaged Bindings",
+			fmt.Sprintf("Security groups bound to managed spaces but not in bindings of %s:\n%s", state.Id.ValueString(), strings.Join(details, "\n")),
+		)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	var refreshedBinds []bind
+	state.Bind.ElementsAs(ctx, &refreshedBinds, false)
+	resp.Diagnostics.Append(setBindIdentity(ctx, resp.Identity, state, refreshedBinds)...)
 }
 
 func (r *cfsecurityBindResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -273,12 +565,38 @@ func (r *cfsecurityBindResource) Update(ctx context.Context, req resource.Update
 	var planBinds, stateBinds []bind
 	plan.Bind.ElementsAs(ctx, &planBinds, false)
 	state.Bind.ElementsAs(ctx, &stateBinds, false)
-	remove, add := getListBindChanges(stateBinds, planBinds)
+	plan.Expired = appliedExpired(plan.Expired, plan.ExpiresAt)
+	unbound, diags := getUnboundOnExpiry(ctx, req.Private, state.Expired)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	remove, add := getListBindChanges(activeBinds(stateBinds, unbound), activeBinds(planBinds, plan.Expired))
+
+	skipped, diags := getSkippedBindings(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// apps may have been started since plan, check again before unbinding
+	disrupted, diags := checkDisruption(r.session.V3(), removableBinds(remove, skipped), disruptionAllowed(plan.AllowDisruption, plan.Expired.ValueBool()))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	disruptedApps, aErr := appliedDisruptedApps(ctx, plan.DisruptedApps, disrupted)
+	resp.Diagnostics.Append(aErr...)
+	plan.DisruptedApps = disruptedApps
 
 	if len(remove) > 0 {
 		for _, rBind := range remove {
-			err := r.client.UnBindSecurityGroup(rBind.AsgID.ValueString(), rBind.SpaceID.ValueString(), r.client.GetEndpoint())
-			if err != nil && !isNotFoundErr(err) {
+			if funk.ContainsString(skipped, bindKey(rBind)) {
+				skipped = funk.SubtractString(skipped, []string{bindKey(rBind)})
+				continue
+			}
+			err := unbindSecurityGroupForLifecycle(r.client, r.session.V3(), rBind.AsgID.ValueString(), rBind.SpaceID.ValueString(), rBind.Lifecycle.ValueString())
+			if err != nil {
 				resp.Diagnostics.AddError(
 					"Client Error",
 					fmt.Sprintf("Unable to unbind security group, got error: %s", err),
@@ -288,20 +606,24 @@ func (r *cfsecurityBindResource) Update(ctx context.Context, req resource.Update
 		}
 	}
 	if len(add) > 0 {
-		for _, aBind := range add {
-			err := r.client.BindSecurityGroup(aBind.AsgID.ValueString(), aBind.SpaceID.ValueString(), r.client.GetEndpoint())
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Client Error",
-					fmt.Sprintf("Unable to bind security group, got error: %s", err),
-				)
-				return
-			}
+		added, diags := r.bindWithExisting(add, plan.OnExisting.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
+		skipped = append(skipped, added...)
+	}
+	resp.Diagnostics.Append(setSkippedBindings(ctx, resp.Private, skipped)...)
+	resp.Diagnostics.Append(setUnboundOnExpiry(ctx, resp.Private, plan.Expired)...)
+
+	resp.Diagnostics.Append(r.refreshUnmanagedBindings(ctx, &plan, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setBindIdentity(ctx, resp.Identity, plan, planBinds)...)
 }
 
 func (r *cfsecurityBindResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -323,12 +645,35 @@ func (r *cfsecurityBindResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	skipped, diags := getSkippedBindings(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unbound, diags := getUnboundOnExpiry(ctx, req.Private, state.Expired)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var binds []bind
 	state.Bind.ElementsAs(ctx, &binds, false)
+	binds = activeBinds(binds, unbound)
+
+	_, diags = checkDisruption(r.session.V3(), removableBinds(binds, skipped), disruptionAllowed(state.AllowDisruption, isExpired(state.ExpiresAt)))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	for _, bind := range binds {
-		err := r.client.UnBindSecurityGroup(bind.AsgID.ValueString(), bind.SpaceID.ValueString(), r.client.GetEndpoint())
-		if err != nil && !isNotFoundErr(err) {
+		// bindings which existed before creation are left as they were found
+		if funk.ContainsString(skipped, bindKey(bind)) {
+			continue
+		}
+		err := unbindSecurityGroupForLifecycle(r.client, r.session.V3(), bind.AsgID.ValueString(), bind.SpaceID.ValueString(), bind.Lifecycle.ValueString())
+		if err != nil {
 			resp.Diagnostics.AddError(
 				"Client Error",
 				fmt.Sprintf("Unable to unbind security group, got error: %s", err),
@@ -338,8 +683,424 @@ func (r *cfsecurityBindResource) Delete(ctx context.Context, req resource.Delete
 	}
 }
 
+// bindWithExisting bind security groups to spaces for the lifecycle of each binding, existing bindings are handled
+// according to onExisting: adopted (only completed if not bound for every lifecycle of the binding), reported as error,
+// or skipped and their keys returned
+func (r *cfsecurityBindResource) bindWithExisting(binds []bind, onExisting string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	skipped := make([]string, 0)
+
+	secGroups, err := r.client.GetSecGroups([]ccv3.Query{}, 0)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get security groups : %s", err),
+		)
+		return nil, diags
+	}
+
+	for _, aBind := range binds {
+		asgID := aBind.AsgID.ValueString()
+		spaceID := aBind.SpaceID.ValueString()
+		lifecycle := aBind.Lifecycle.ValueString()
+		var boundRunning, boundStaging bool
+		for _, secGroup := range secGroups.Resources {
+			if secGroup.GUID == asgID {
+				boundRunning = lifecycle != lifecycleStaging && isSecurityGroupBound(secGroup, spaceID, lifecycleRunning)
+				boundStaging = lifecycle != lifecycleRunning && isSecurityGroupBound(secGroup, spaceID, lifecycleStaging)
+				break
+			}
+		}
+
+		if boundRunning || boundStaging {
+			switch onExisting {
+			case onExistingError:
+				diags.AddError(
+					"Binding Already Exists",
+					fmt.Sprintf("Security group %s is already bound to space %s, import it or set on_existing to adopt or skip", asgID, spaceID),
+				)
+				return skipped, diags
+			case onExistingSkip:
+				skipped = append(skipped, bindKey(aBind))
+				continue
+			}
+			if (boundRunning || lifecycle == lifecycleStaging) && (boundStaging || lifecycle == lifecycleRunning) {
+				continue
+			}
+		}
+
+		err := bindSecurityGroupForLifecycle(r.client, asgID, spaceID, lifecycle)
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to bind security group, got error: %s", err),
+			)
+			return skipped, diags
+		}
+	}
+	return skipped, diags
+}
+
+// removableBinds return binds which are really unbound when removed, bindings skipped on creation are left as they are
+func removableBinds(binds []bind, skipped []string) []bind {
+	removable := make([]bind, 0, len(binds))
+	for _, aBind := range binds {
+		if !funk.ContainsString(skipped, bindKey(aBind)) {
+			removable = append(removable, aBind)
+		}
+	}
+	return removable
+}
+
+// bindKey return a key identifying a binding, lifecycle is only part of it when set to keep keys of bindings for both lifecycles unchanged
+func bindKey(aBind bind) string {
+	key := aBind.AsgID.ValueString() + "/" + aBind.SpaceID.ValueString()
+	if aBind.Lifecycle.ValueString() != "" {
+		key += "/" + aBind.Lifecycle.ValueString()
+	}
+	return key
+}
+
+// privateState is implemented by private state of requests and responses
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// getSkippedBindings return keys of bindings skipped on creation from private state
+func getSkippedBindings(ctx context.Context, private privateState) ([]string, diag.Diagnostics) {
+	skipped := make([]string, 0)
+	value, diags := private.GetKey(ctx, skippedBindingsKey)
+	if diags.HasError() || len(value) == 0 {
+		return skipped, diags
+	}
+	if err := json.Unmarshal(value, &skipped); err != nil {
+		diags.AddError(
+			"Private State Error",
+			fmt.Sprintf("Unable to decode skipped bindings: %s", err),
+		)
+	}
+	return skipped, diags
+}
+
+// setSkippedBindings save keys of bindings skipped on creation in private state
+func setSkippedBindings(ctx context.Context, private privateState, skipped []string) diag.Diagnostics {
+	sort.Strings(skipped)
+	value, err := json.Marshal(skipped)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Private State Error",
+			fmt.Sprintf("Unable to encode skipped bindings: %s", err),
+		)
+		return diags
+	}
+	return private.SetKey(ctx, skippedBindingsKey, value)
+}
+
+// getUnboundOnExpiry return whether bindings have been unbound by the last apply because they expired,
+// expired is used for states written before it was kept in private state
+func getUnboundOnExpiry(ctx context.Context, private privateState, expired types.Bool) (types.Bool, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, unboundOnExpiryKey)
+	if diags.HasError() || len(value) == 0 {
+		return types.BoolValue(expired.ValueBool()), diags
+	}
+	var unbound bool
+	if err := json.Unmarshal(value, &unbound); err != nil {
+		diags.AddError(
+			"Private State Error",
+			fmt.Sprintf("Unable to decode unbound on expiry: %s", err),
+		)
+	}
+	return types.BoolValue(unbound), diags
+}
+
+// setUnboundOnExpiry save in private state whether bindings have been unbound because they expired
+func setUnboundOnExpiry(ctx context.Context, private privateState, expired types.Bool) diag.Diagnostics {
+	value, _ := json.Marshal(expired.ValueBool())
+	return private.SetKey(ctx, unboundOnExpiryKey, value)
+}
+
+// refreshUnmanagedBindings set unmanaged_bindings to security groups bound to spaces of bindings which are not in bindings,
+// it is null when unmanaged bindings are not reported, security groups are fetched when not given
+func (r *cfsecurityBindResource) refreshUnmanagedBindings(ctx context.Context, data *cfsecurityBindResourceModel, secGroups *client.SecurityGroups) diag.Diagnostics {
+	var diags diag.Diagnostics
+	data.UnmanagedBindings = types.SetNull(unmanagedBindingType)
+	if !data.ReportUnmanaged.ValueBool() || data.Force.ValueBool() {
+		return diags
+	}
+
+	if secGroups == nil {
+		allSecGroups, err := r.client.GetSecGroups([]ccv3.Query{}, 0)
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to get security groups : %s", err),
+			)
+			return diags
+		}
+		secGroups = &allSecGroups
+	}
+
+	var binds []bind
+	data.Bind.ElementsAs(ctx, &binds, false)
+	spaceIDs := make([]string, 0)
+	for _, aBind := range binds {
+		spaceIDs = append(spaceIDs, aBind.SpaceID.ValueString())
+	}
+
+	unmanaged := make([]unmanagedBinding, 0)
+	for _, secGroup := range secGroups.Resources {
+		for _, spaceID := range funk.UniqString(spaceIDs) {
+			if !isSecurityGroupBound(secGroup, spaceID, lifecycleRunning) && !isSecurityGroupBound(secGroup, spaceID, lifecycleStaging) {
+				continue
+			}
+			if isInSlice(binds, func(object interface{}) bool {
+				aBind := object.(bind)
+				return aBind.AsgID.ValueString() == secGroup.GUID && aBind.SpaceID.ValueString() == spaceID
+			}) {
+				continue
+			}
+			unmanaged = append(unmanaged, unmanagedBinding{
+				AsgID:   types.StringValue(secGroup.GUID),
+				AsgName: types.StringValue(secGroup.Name),
+				SpaceID: types.StringValue(spaceID),
+			})
+		}
+	}
+
+	unmanagedSet, aErr := types.SetValueFrom(ctx, unmanagedBindingType, unmanaged)
+	diags.Append(aErr...)
+	if !diags.HasError() {
+		data.UnmanagedBindings = unmanagedSet
+	}
+	return diags
+}
+
+// ModifyPlan check that security groups and spaces of bindings exist, report bindings which have no effect
+// and check that the current user can bind to these spaces,
+// to fail at plan rather than in the middle of an apply
+func (r *cfsecurityBindResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when provider is not yet configured
+	if r.client == nil {
+		return
+	}
+
+	err := refreshTokenIfExpired(r.client, r.config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to refresh token: %s", err),
+		)
+		return
+	}
+
+	skipped, diags := getSkippedBindings(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// on destroy, only check apps disrupted by unbinding everything
+	if req.Plan.Raw.IsNull() {
+		var state cfsecurityBindResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		unbound, diags := getUnboundOnExpiry(ctx, req.Private, state.Expired)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		var stateBinds []bind
+		state.Bind.ElementsAs(ctx, &stateBinds, false)
+		_, diags = checkDisruption(r.session.V3(), removableBinds(activeBinds(stateBinds, unbound), skipped), disruptionAllowed(state.AllowDisruption, isExpired(state.ExpiresAt)))
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	var plan cfsecurityBindResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Bind.IsUnknown() {
+		return
+	}
+
+	var state cfsecurityBindResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	resp.Diagnostics.Append(checkExpiresAt(r.options, plan.ExpiresAt, state.ExpiresAt, req.State.Raw.IsNull())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	expired := setPlanExpired(ctx, resp, plan.ExpiresAt)
+	unbound, diags := getUnboundOnExpiry(ctx, req.Private, state.Expired)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// refresh already reports bindings as expired, they are still unbound by apply
+	if expired && !req.State.Raw.IsNull() && !unbound.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expired"), types.BoolUnknown())...)
+	}
+
+	asgPaths := make(map[string][]path.Path)
+	spacePaths := make(map[string][]path.Path)
+	bindPaths := make(map[string][]path.Path)
+	// security groups are checked for the lifecycle of their bindings
+	lifecycleAsgPaths := make(map[string]map[string][]path.Path)
+	for _, elem := range plan.Bind.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsUnknown() {
+			continue
+		}
+		elemPath := path.Root("bind").AtSetValue(elem)
+		asgID, _ := obj.Attributes()["asg_id"].(types.String)
+		spaceID, _ := obj.Attributes()["space_id"].(types.String)
+		lifecycle, _ := obj.Attributes()["lifecycle"].(types.String)
+		addGUIDPath(asgPaths, asgID, elemPath.AtName("asg_id"))
+		addGUIDPath(spacePaths, spaceID, elemPath.AtName("space_id"))
+		if _, ok := lifecycleAsgPaths[lifecycle.ValueString()]; !ok {
+			lifecycleAsgPaths[lifecycle.ValueString()] = make(map[string][]path.Path)
+		}
+		addGUIDPath(lifecycleAsgPaths[lifecycle.ValueString()], asgID, elemPath.AtName("asg_id"))
+		if !asgID.IsUnknown() && !spaceID.IsUnknown() && !lifecycle.IsUnknown() {
+			addGUIDPath(bindPaths, types.StringValue(bindKey(bind{AsgID: asgID, SpaceID: spaceID, Lifecycle: lifecycle})), elemPath)
+		}
+	}
+	resp.Diagnostics.Append(checkSecurityGroupsExist(r.client, asgPaths)...)
+	resp.Diagnostics.Append(checkSpacesExist(r.client, spacePaths)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkDuplicateGUIDs(r.options, "Binding", bindPaths)...)
+	for lifecycle, paths := range lifecycleAsgPaths {
+		resp.Diagnostics.Append(checkGloballyEnabledSecurityGroups(r.session.V3(), r.options, paths, lifecycle)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only spaces where bindings are added or removed need permissions
+	var planBinds, stateBinds []bind
+	plan.Bind.ElementsAs(ctx, &planBinds, false)
+	state.Bind.ElementsAs(ctx, &stateBinds, false)
+	remove, add := getListBindChanges(activeBinds(stateBinds, unbound), activeBinds(planBinds, types.BoolValue(expired)))
+
+	changedSpacePaths := make(map[string][]path.Path)
+	for _, aBind := range add {
+		changedSpacePaths[aBind.SpaceID.ValueString()] = spacePaths[aBind.SpaceID.ValueString()]
+	}
+	for _, rBind := range remove {
+		if _, ok := changedSpacePaths[rBind.SpaceID.ValueString()]; !ok {
+			changedSpacePaths[rBind.SpaceID.ValueString()] = []path.Path{path.Root("bind")}
+		}
+	}
+	resp.Diagnostics.Append(checkUserManagesSpaces(r.client, r.session.V3(), changedSpacePaths)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	disrupted, diags := checkDisruption(r.session.V3(), removableBinds(remove, skipped), disruptionAllowed(plan.AllowDisruption, expired))
+	resp.Diagnostics.Append(diags...)
+	setPlanDisruptedApps(ctx, req, resp, disrupted)
+}
+
+// ImportState import by id, or by identity: either the binding given by identity, or all security groups bound to the space
+// of identity with force set when no security group is given, bindings are then checked on the following read,
+// identity is validated against state on every read
 func (r *cfsecurityBindResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID != "" {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	var identity cfsecurityBindResourceIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if identity.Id.ValueString() != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Invalid Identity",
+			"Resources with several bindings can not be imported by identity, import them by id",
+		)
+		return
+	}
+	resp.Diagnostics.Append(checkIdentityGUIDs("space_guid", identity.SpaceGUID.ValueString())...)
+	if identity.AsgGUID.ValueString() != "" {
+		resp.Diagnostics.Append(checkIdentityGUIDs("asg_guid", identity.AsgGUID.ValueString())...)
+	} else if identity.Lifecycle.ValueString() != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("lifecycle"),
+			"Invalid Identity",
+			"lifecycle can only be given with asg_guid, security groups of the space are imported with their own lifecycle otherwise",
+		)
+	}
+	if lifecycle := identity.Lifecycle.ValueString(); lifecycle != "" && lifecycle != lifecycleRunning && lifecycle != lifecycleStaging {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("lifecycle"),
+			"Invalid Identity",
+			fmt.Sprintf("lifecycle must be %s or %s, got %q", lifecycleRunning, lifecycleStaging, lifecycle),
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	force := identity.AsgGUID.ValueString() == ""
+	var binds []bind
+	if force {
+		err := refreshTokenIfExpired(r.client, r.config)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to refresh token: %s", err),
+			)
+			return
+		}
+		secGroups, err := r.client.GetSecGroups([]ccv3.Query{}, 0)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to get security groups : %s", err),
+			)
+			return
+		}
+		binds = spaceBinds(secGroups.Resources, identity.SpaceGUID.ValueString())
+		if len(binds) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("space_guid"),
+				"Invalid Identity",
+				fmt.Sprintf("No security group bound to space %s", identity.SpaceGUID.ValueString()),
+			)
+			return
+		}
+	} else {
+		lifecycle := types.StringNull()
+		if identity.Lifecycle.ValueString() != "" {
+			lifecycle = identity.Lifecycle
+		}
+		binds = []bind{{
+			AsgID:     identity.AsgGUID,
+			SpaceID:   identity.SpaceGUID,
+			Lifecycle: lifecycle,
+		}}
+	}
+
+	data, diags := newBindResourceModel(ctx, resp.State.Schema.GetBlocks()["bind"].(schema.SetNestedBlock).NestedObject.Type(), binds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if force {
+		data.Force = types.BoolValue(true)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setBindIdentity(ctx, resp.Identity, data, binds)...)
 }
 
 // ValidateConfig Called during terraform validate through ValidateResourceConfig RPC
@@ -353,11 +1114,262 @@ func (r *cfsecurityBindResource) ValidateConfig(ctx context.Context, req resourc
 		return
 	}
 
+	if !configData.OnExisting.IsNull() && !configData.OnExisting.IsUnknown() &&
+		!funk.ContainsString([]string{onExistingAdopt, onExistingError, onExistingSkip}, configData.OnExisting.ValueString()) {
+		resp.Diagnostics.AddAttributeError(path.Root("on_existing"), "Attribute Error", "\"on_existing\" must be either \"adopt\", \"error\" or \"skip\".")
+	}
+
 	var binds []bind
 	configData.Bind.ElementsAs(ctx, &binds, false)
 	for _, bind := range binds {
 		if bind.AsgID.IsNull() || bind.SpaceID.IsNull() {
 			resp.Diagnostics.AddAttributeError(path.Root("bind"), "Attribute Error", "\"asg_id\" and \"space_id\" fields must be provided.")
 		}
+		if !isValidLifecycle(bind.Lifecycle) {
+			resp.Diagnostics.AddAttributeError(path.Root("bind"), "Attribute Error", "\"lifecycle\" must be either \"running\" or \"staging\".")
+		}
+	}
+}
+
+// MoveState convert state with a moved block from:
+//   - deprecated cfsecurity_entitle_asg, each entitlement become bindings of the security group to every space of the entitled org
+//   - security groups bindings of cloudfoundry providers resources, see moveFromCloudfoundry
+func (r *cfsecurityBindResource) MoveState(context.Context) []resource.StateMover {
+	sourceSchema := entitleAsgSchema()
+	return []resource.StateMover{
+		{
+			SourceSchema: &sourceSchema,
+			StateMover:   r.moveFromEntitleAsg,
+		},
+		{
+			StateMover: r.moveFromCloudfoundry,
+		},
+	}
+}
+
+func (r *cfsecurityBindResource) moveFromEntitleAsg(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "cfsecurity_entitle_asg" || req.SourceState == nil {
+		return
+	}
+	if r.session == nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			"Provider must be configured to move cfsecurity_entitle_asg state",
+		)
+		return
+	}
+
+	var source cfsecurityEntitleAsgResourceModel
+	resp.Diagnostics.Append(req.SourceState.Get(ctx, &source)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var entitlements []entitle
+	source.Entitle.ElementsAs(ctx, &entitlements, false)
+
+	finalBinds := make([]bind, 0)
+	spacesByOrg := make(map[string][]resources.Space)
+	for _, entitlement := range entitlements {
+		orgID := entitlement.OrgID.ValueString()
+		spaces, ok := spacesByOrg[orgID]
+		if !ok {
+			var err error
+			spaces, _, _, err = r.session.V3().GetSpaces(ccv3.Query{Key: ccv3.OrganizationGUIDFilter, Values: []string{orgID}})
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Client Error",
+					fmt.Sprintf("Unable to get spaces of org %s: %s", orgID, err),
+				)
+				return
+			}
+			spacesByOrg[orgID] = spaces
+		}
+		for _, space := range spaces {
+			finalBinds = append(finalBinds, bind{
+				AsgID:   entitlement.AsgID,
+				SpaceID: types.StringValue(space.GUID),
+			})
+		}
+	}
+	setMovedBindState(ctx, resp, finalBinds)
+}
+
+// cloudfoundryBindingsRawState hold security groups bindings found in state of cloudfoundry providers resources
+type cloudfoundryBindingsRawState struct {
+	ID string `json:"id"`
+	// cloudfoundry_space of cloudfoundry-community provider, id is the space guid
+	Asgs        []string `json:"asgs"`
+	StagingAsgs []string `json:"staging_asgs"`
+	// cloudfoundry_space_asgs of cloudfoundry-community provider
+	Space       string   `json:"space"`
+	RunningAsgs []string `json:"running_asgs"`
+	// cloudfoundry_security_group_space_bindings of cloudfoundry provider
+	SecurityGroup string   `json:"security_group"`
+	RunningSpaces []string `json:"running_spaces"`
+	StagingSpaces []string `json:"staging_spaces"`
+}
+
+// moveFromCloudfoundry convert security groups bindings of cloudfoundry providers resources, running and staging
+// bindings become bind blocks for their lifecycle, nothing is changed on the platform:
+//   - cloudfoundry_space (asgs and staging_asgs) and cloudfoundry_space_asgs of cloudfoundry-community provider
+//   - cloudfoundry_security_group_space_bindings of cloudfoundry provider
+func (r *cfsecurityBindResource) moveFromCloudfoundry(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceRawState == nil {
+		return
+	}
+
+	var source cloudfoundryBindingsRawState
+	finalBinds := make([]bind, 0)
+	addBinds := func(asgIDs []string, spaceIDs []string, lifecycle string) {
+		for _, aBind := range asgSpacesBinds(asgIDs, spaceIDs) {
+			aBind.Lifecycle = types.StringValue(lifecycle)
+			finalBinds = append(finalBinds, aBind)
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(req.SourceProviderAddress, "/cloudfoundry-community/cloudfoundry") && req.SourceTypeName == "cloudfoundry_space":
+		if !unmarshalMovedRawState(req, resp, &source) {
+			return
+		}
+		addBinds(source.Asgs, []string{source.ID}, lifecycleRunning)
+		addBinds(source.StagingAsgs, []string{source.ID}, lifecycleStaging)
+	case strings.HasSuffix(req.SourceProviderAddress, "/cloudfoundry-community/cloudfoundry") && req.SourceTypeName == "cloudfoundry_space_asgs":
+		if !unmarshalMovedRawState(req, resp, &source) {
+			return
+		}
+		addBinds(source.RunningAsgs, []string{source.Space}, lifecycleRunning)
+		addBinds(source.StagingAsgs, []string{source.Space}, lifecycleStaging)
+	case strings.HasSuffix(req.SourceProviderAddress, "/cloudfoundry/cloudfoundry") && req.SourceTypeName == "cloudfoundry_security_group_space_bindings":
+		if !unmarshalMovedRawState(req, resp, &source) {
+			return
+		}
+		addBinds([]string{source.SecurityGroup}, source.RunningSpaces, lifecycleRunning)
+		addBinds([]string{source.SecurityGroup}, source.StagingSpaces, lifecycleStaging)
+	default:
+		return
+	}
+
+	setMovedBindState(ctx, resp, finalBinds)
+}
+
+// unmarshalMovedRawState decode raw state of moved resource, return false if it can not be decoded
+func unmarshalMovedRawState(req resource.MoveStateRequest, resp *resource.MoveStateResponse, source interface{}) bool {
+	if err := json.Unmarshal(req.SourceRawState.JSON, source); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Move State",
+			fmt.Sprintf("Unable to decode state of %s: %s", req.SourceTypeName, err),
+		)
+		return false
+	}
+	return true
+}
+
+// setMovedBindState set target state of a move to the given bindings
+func setMovedBindState(ctx context.Context, resp *resource.MoveStateResponse, finalBinds []bind) {
+	data, diags := newBindResourceModel(ctx, resp.TargetState.Schema.GetBlocks()["bind"].(schema.SetNestedBlock).NestedObject.Type(), finalBinds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, &data)...)
+}
+
+// newBindResourceModel return the model of a resource holding given bindings with default settings,
+// as found when its state is moved, imported or listed
+func newBindResourceModel(ctx context.Context, bindType attr.Type, binds []bind) (cfsecurityBindResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	binds = funk.Uniq(binds).([]bind)
+
+	id, err := bindResourceID(binds)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to compute id: %s", err),
+		)
+		return cfsecurityBindResourceModel{}, diags
+	}
+
+	bindSet, aErr := types.SetValueFrom(ctx, bindType, binds)
+	diags.Append(aErr...)
+	if diags.HasError() {
+		return cfsecurityBindResourceModel{}, diags
+	}
+
+	return cfsecurityBindResourceModel{
+		Id:                types.StringValue(id),
+		Bind:              bindSet,
+		Force:             types.BoolNull(),
+		ReportUnmanaged:   types.BoolNull(),
+		UnmanagedBindings: types.SetNull(unmanagedBindingType),
+		RemoveWhenGone:    types.BoolNull(),
+		OnExisting:        types.StringNull(),
+		AllowDisruption:   types.BoolNull(),
+		DisruptedApps:     types.SetValueMust(disruptedAppType, []attr.Value{}),
+		ExpiresAt:         types.StringNull(),
+		Expired:           types.BoolValue(false),
+	}, diags
+}
+
+// bindResourceIdentity return identity of the resource:
+//   - the space of its bindings when force is true, all security groups bound to the space belong to the resource
+//   - its binding when it has a single one
+//   - its id otherwise, bindings can not identify it as they may be shared with other resources
+func bindResourceIdentity(id string, force bool, binds []bind) cfsecurityBindResourceIdentityModel {
+	identity := cfsecurityBindResourceIdentityModel{
+		Id:        types.StringNull(),
+		AsgGUID:   types.StringNull(),
+		SpaceGUID: types.StringNull(),
+		Lifecycle: types.StringNull(),
+	}
+	if force && len(binds) > 0 && !isInSlice(binds, func(object interface{}) bool {
+		return object.(bind).SpaceID.ValueString() != binds[0].SpaceID.ValueString()
+	}) {
+		identity.SpaceGUID = types.StringValue(binds[0].SpaceID.ValueString())
+		return identity
+	}
+	if !force && len(binds) == 1 {
+		identity.AsgGUID = types.StringValue(binds[0].AsgID.ValueString())
+		identity.SpaceGUID = types.StringValue(binds[0].SpaceID.ValueString())
+		if binds[0].Lifecycle.ValueString() != "" {
+			identity.Lifecycle = types.StringValue(binds[0].Lifecycle.ValueString())
+		}
+		return identity
+	}
+	identity.Id = types.StringValue(id)
+	return identity
+}
+
+// setBindIdentity set identity of the resource from data and its bindings, nothing is done when terraform does not support identity
+func setBindIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, data cfsecurityBindResourceModel, binds []bind) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, bindResourceIdentity(data.Id.ValueString(), data.Force.ValueBool(), binds))
+}
+
+// spaceBinds return a binding to spaceGUID of each security group bound to it,
+// a security group bound for a single lifecycle is kept with this lifecycle
+func spaceBinds(secGroups []client.SecurityGroup, spaceGUID string) []bind {
+	binds := make([]bind, 0)
+	for _, secGroup := range secGroups {
+		boundRunning := isSecurityGroupBound(secGroup, spaceGUID, lifecycleRunning)
+		boundStaging := isSecurityGroupBound(secGroup, spaceGUID, lifecycleStaging)
+		if !boundRunning && !boundStaging {
+			continue
+		}
+		lifecycle := types.StringNull()
+		if !boundRunning {
+			lifecycle = types.StringValue(lifecycleStaging)
+		} else if !boundStaging {
+			lifecycle = types.StringValue(lifecycleRunning)
+		}
+		binds = append(binds, bind{
+			AsgID:     types.StringValue(secGroup.GUID),
+			SpaceID:   types.StringValue(spaceGUID),
+			Lifecycle: lifecycle,
+		})
 	}
+	return binds
 }
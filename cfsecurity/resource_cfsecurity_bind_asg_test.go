@@ -0,0 +1,274 @@
+package cfsecurity
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+)
+
+// newTestBind return a binding of asgID to spaceID, for both lifecycles when lifecycle is empty
+func newTestBind(asgID, spaceID, lifecycle string) bind {
+	aBind := bind{AsgID: types.StringValue(asgID), SpaceID: types.StringValue(spaceID), Lifecycle: types.StringNull()}
+	if lifecycle != "" {
+		aBind.Lifecycle = types.StringValue(lifecycle)
+	}
+	return aBind
+}
+
+func TestBindResourceID(t *testing.T) {
+	const (
+		asg1   = "dcee7d89-149b-4bab-9eb9-1e5e73c22aae"
+		asg2   = "ce9ee907-74a2-4226-a5b2-5b6336973a9e"
+		space1 = "7e0477b9-fff8-41b1-8fd8-969095ba62e5"
+	)
+	tests := []struct {
+		name  string
+		binds []bind
+		want  string
+	}{
+		// ids already in states must never change
+		{name: "no binding", binds: nil, want: "e3b0c442-98fc-1c14-9afb-f4c8996fb924"},
+		{name: "binding for both lifecycles", binds: []bind{newTestBind(asg1, space1, "")}, want: "017e2865-3365-4232-aa13-23741b2e0857"},
+		{name: "bindings with lifecycle", binds: []bind{newTestBind(asg1, space1, lifecycleStaging), newTestBind(asg1, space1, "")}, want: "180b8d15-15e3-62a7-8857-767d16ca2d8c"},
+		{name: "order does not matter", binds: []bind{newTestBind(asg1, space1, ""), newTestBind(asg1, space1, lifecycleStaging)}, want: "180b8d15-15e3-62a7-8857-767d16ca2d8c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bindResourceID(tt.binds)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("got id %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("different bindings give different ids", func(t *testing.T) {
+		ids := make(map[string]string)
+		for name, binds := range map[string][]bind{
+			"asg1":         {newTestBind(asg1, space1, "")},
+			"asg2":         {newTestBind(asg2, space1, "")},
+			"asg1 running": {newTestBind(asg1, space1, lifecycleRunning)},
+			"asg1 staging": {newTestBind(asg1, space1, lifecycleStaging)},
+			"asg1 asg2":    {newTestBind(asg1, space1, ""), newTestBind(asg2, space1, "")},
+		} {
+			id, err := bindResourceID(binds)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if other, ok := ids[id]; ok {
+				t.Fatalf("bindings %s and %s give the same id %s", name, other, id)
+			}
+			ids[id] = name
+		}
+	})
+}
+
+func TestMoveFromCloudfoundry(t *testing.T) {
+	ctx := context.Background()
+	r := &cfsecurityBindResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	tests := []struct {
+		name     string
+		provider string
+		typeName string
+		state    string
+		want     []string
+	}{
+		{
+			name:     "cloudfoundry_space",
+			provider: "registry.terraform.io/cloudfoundry-community/cloudfoundry",
+			typeName: "cloudfoundry_space",
+			state:    `{"id":"space-1","asgs":["asg-1","asg-2"],"staging_asgs":["asg-1"]}`,
+			want:     []string{"asg-1/space-1/running", "asg-1/space-1/staging", "asg-2/space-1/running"},
+		},
+		{
+			name:     "cloudfoundry_space_asgs",
+			provider: "registry.terraform.io/cloudfoundry-community/cloudfoundry",
+			typeName: "cloudfoundry_space_asgs",
+			state:    `{"space":"space-1","running_asgs":["asg-1"],"staging_asgs":["asg-2"]}`,
+			want:     []string{"asg-1/space-1/running", "asg-2/space-1/staging"},
+		},
+		{
+			name:     "cloudfoundry_security_group_space_bindings",
+			provider: "registry.terraform.io/cloudfoundry/cloudfoundry",
+			typeName: "cloudfoundry_security_group_space_bindings",
+			state:    `{"security_group":"asg-1","running_spaces":["space-1","space-2"],"staging_spaces":["space-2"]}`,
+			want:     []string{"asg-1/space-1/running", "asg-1/space-2/running", "asg-1/space-2/staging"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := resource.MoveStateRequest{
+				SourceProviderAddress: tt.provider,
+				SourceTypeName:        tt.typeName,
+				SourceRawState:        &tfprotov6.RawState{JSON: []byte(tt.state)},
+			}
+			resp := resource.MoveStateResponse{
+				TargetState: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				},
+			}
+			r.moveFromCloudfoundry(ctx, req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var data cfsecurityBindResourceModel
+			resp.Diagnostics.Append(resp.TargetState.Get(ctx, &data)...)
+			var binds []bind
+			resp.Diagnostics.Append(data.Bind.ElementsAs(ctx, &binds, false)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			got := make([]string, 0, len(binds))
+			for _, aBind := range binds {
+				got = append(got, bindKey(aBind))
+			}
+			sort.Strings(got)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got binds %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got binds %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// fakePrivateState keep private state keys in memory
+type fakePrivateState map[string][]byte
+
+func (p fakePrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p fakePrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestUnboundOnExpiry(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		applied *bool
+		expired types.Bool
+		want    bool
+	}{
+		// states written before it was kept in private state
+		{name: "not saved and not expired", expired: types.BoolValue(false), want: false},
+		{name: "not saved and expired", expired: types.BoolValue(true), want: true},
+		{name: "not saved and imported", expired: types.BoolNull(), want: false},
+		// expired is refreshed from expires_at before bindings are unbound by apply
+		{name: "bound by apply and now expired", applied: new(bool), expired: types.BoolValue(true), want: false},
+		{name: "unbound by apply", applied: func() *bool { b := true; return &b }(), expired: types.BoolValue(true), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			private := fakePrivateState{}
+			if tt.applied != nil {
+				if diags := setUnboundOnExpiry(ctx, private, types.BoolValue(*tt.applied)); diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+			}
+			got, diags := getUnboundOnExpiry(ctx, private, tt.expired)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got.ValueBool() != tt.want {
+				t.Errorf("got %s, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBindResourceIdentity(t *testing.T) {
+	null := types.StringNull()
+	tests := []struct {
+		name  string
+		force bool
+		binds []bind
+		want  cfsecurityBindResourceIdentityModel
+	}{
+		{
+			name: "no binding",
+			want: cfsecurityBindResourceIdentityModel{Id: types.StringValue("id-1"), AsgGUID: null, SpaceGUID: null, Lifecycle: null},
+		},
+		{
+			name:  "binding for both lifecycles",
+			binds: []bind{newTestBind("asg-1", "space-1", "")},
+			want:  cfsecurityBindResourceIdentityModel{Id: null, AsgGUID: types.StringValue("asg-1"), SpaceGUID: types.StringValue("space-1"), Lifecycle: null},
+		},
+		{
+			name:  "binding with lifecycle",
+			binds: []bind{newTestBind("asg-1", "space-1", lifecycleStaging)},
+			want:  cfsecurityBindResourceIdentityModel{Id: null, AsgGUID: types.StringValue("asg-1"), SpaceGUID: types.StringValue("space-1"), Lifecycle: types.StringValue(lifecycleStaging)},
+		},
+		{
+			name:  "several bindings",
+			binds: []bind{newTestBind("asg-1", "space-1", lifecycleStaging), newTestBind("asg-1", "space-1", lifecycleRunning)},
+			want:  cfsecurityBindResourceIdentityModel{Id: types.StringValue("id-1"), AsgGUID: null, SpaceGUID: null, Lifecycle: null},
+		},
+		{
+			name:  "force",
+			force: true,
+			binds: []bind{newTestBind("asg-1", "space-1", ""), newTestBind("asg-2", "space-1", lifecycleRunning)},
+			want:  cfsecurityBindResourceIdentityModel{Id: null, AsgGUID: null, SpaceGUID: types.StringValue("space-1"), Lifecycle: null},
+		},
+		{
+			name:  "force with a single binding",
+			force: true,
+			binds: []bind{newTestBind("asg-1", "space-1", lifecycleRunning)},
+			want:  cfsecurityBindResourceIdentityModel{Id: null, AsgGUID: null, SpaceGUID: types.StringValue("space-1"), Lifecycle: null},
+		},
+		{
+			name:  "force on several spaces",
+			force: true,
+			binds: []bind{newTestBind("asg-1", "space-1", ""), newTestBind("asg-1", "space-2", "")},
+			want:  cfsecurityBindResourceIdentityModel{Id: types.StringValue("id-1"), AsgGUID: null, SpaceGUID: null, Lifecycle: null},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bindResourceIdentity("id-1", tt.force, tt.binds)
+			if !got.Id.Equal(tt.want.Id) || !got.AsgGUID.Equal(tt.want.AsgGUID) || !got.SpaceGUID.Equal(tt.want.SpaceGUID) || !got.Lifecycle.Equal(tt.want.Lifecycle) {
+				t.Errorf("got identity %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpaceBinds(t *testing.T) {
+	var both, running, other client.SecurityGroup
+	both.GUID, running.GUID, other.GUID = "asg-both", "asg-running", "asg-other"
+	both.Relationships.Running_Spaces.Data = []client.Data{{GUID: "space-1"}}
+	both.Relationships.Staging_Spaces.Data = []client.Data{{GUID: "space-1"}}
+	running.Relationships.Running_Spaces.Data = []client.Data{{GUID: "space-1"}}
+	other.Relationships.Running_Spaces.Data = []client.Data{{GUID: "space-2"}}
+
+	got := spaceBinds([]client.SecurityGroup{both, running, other}, "space-1")
+	want := []string{bindKey(newTestBind("asg-both", "space-1", "")), bindKey(newTestBind("asg-running", "space-1", lifecycleRunning))}
+	if len(got) != len(want) {
+		t.Fatalf("got binds %+v, want %v", got, want)
+	}
+	for i, aBind := range got {
+		if bindKey(aBind) != want[i] {
+			t.Errorf("got bind %s, want %s", bindKey(aBind), want[i])
+		}
+	}
+}
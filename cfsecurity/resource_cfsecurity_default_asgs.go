@@ -0,0 +1,291 @@
+package cfsecurity
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/v8/resources"
+	clients "github.com/cloudfoundry-community/go-cf-clients-helper/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+	"github.com/thoas/go-funk"
+)
+
+// defaultAsgsID is the id of the cfsecurity_default_asgs resource, there is only one set of default security groups by platform
+const defaultAsgsID = "default_asgs"
+
+type cfsecurityDefaultAsgsResource struct {
+	client  *client.Client
+	config  *clients.Config
+	session *clients.Session
+}
+
+var _ resource.Resource = &cfsecurityDefaultAsgsResource{}
+var _ resource.ResourceWithConfigure = &cfsecurityDefaultAsgsResource{}
+var _ resource.ResourceWithImportState = &cfsecurityDefaultAsgsResource{}
+var _ resource.ResourceWithModifyPlan = &cfsecurityDefaultAsgsResource{}
+
+func NewCFSecurityDefaultAsgsResource(config *clients.Config, session *clients.Session) resource.Resource {
+	return &cfsecurityDefaultAsgsResource{
+		config:  config,
+		session: session,
+	}
+}
+
+type cfsecurityDefaultAsgsResourceModel struct {
+	Id      types.String `tfsdk:"id"`
+	Running types.Set    `tfsdk:"running"`
+	Staging types.Set    `tfsdk:"staging"`
+}
+
+func (r *cfsecurityDefaultAsgsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_default_asgs"
+}
+
+func (r *cfsecurityDefaultAsgsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clt, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clt
+}
+
+func (r *cfsecurityDefaultAsgsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manage security groups globally enabled on the platform, only usable by an admin",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"running": schema.SetAttribute{
+				Description: "Guids of the security groups globally enabled for running apps, others are disabled",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					guidSetValidator{},
+				},
+			},
+			"staging": schema.SetAttribute{
+				Description: "Guids of the security groups globally enabled for staging apps, others are disabled",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					guidSetValidator{},
+				},
+			},
+		},
+	}
+}
+
+func (r *cfsecurityDefaultAsgsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan cfsecurityDefaultAsgsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = types.StringValue(defaultAsgsID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *cfsecurityDefaultAsgsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state cfsecurityDefaultAsgsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkCurrentUserIsAdmin(r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secGroups, _, err := r.session.V3().GetSecurityGroups()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get security groups : %s", err),
+		)
+		return
+	}
+
+	running := make([]string, 0)
+	staging := make([]string, 0)
+	for _, secGroup := range secGroups {
+		if secGroup.RunningGloballyEnabled != nil && *secGroup.RunningGloballyEnabled {
+			running = append(running, secGroup.GUID)
+		}
+		if secGroup.StagingGloballyEnabled != nil && *secGroup.StagingGloballyEnabled {
+			staging = append(staging, secGroup.GUID)
+		}
+	}
+
+	runningSet, aErr := types.SetValueFrom(ctx, types.StringType, running)
+	resp.Diagnostics.Append(aErr...)
+	stagingSet, aErr := types.SetValueFrom(ctx, types.StringType, staging)
+	resp.Diagnostics.Append(aErr...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Id = types.StringValue(defaultAsgsID)
+	state.Running = runningSet
+	state.Staging = stagingSet
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *cfsecurityDefaultAsgsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan cfsecurityDefaultAsgsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = types.StringValue(defaultAsgsID)
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete only remove the resource from the state, globally enabled security groups are left as they are
+// to not cut traffic of every app on the platform
+func (r *cfsecurityDefaultAsgsResource) Delete(context.Context, resource.DeleteRequest, *resource.DeleteResponse) {
+
+}
+
+// ModifyPlan check that security groups exist, to fail at plan rather than in the middle of an apply
+func (r *cfsecurityDefaultAsgsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy or when provider is not yet configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan cfsecurityDefaultAsgsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := refreshTokenIfExpired(r.client, r.config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to refresh token: %s", err),
+		)
+		return
+	}
+
+	asgPaths := make(map[string][]path.Path)
+	addSetGUIDPaths(asgPaths, plan.Running, path.Root("running"))
+	addSetGUIDPaths(asgPaths, plan.Staging, path.Root("staging"))
+	resp.Diagnostics.Append(checkSecurityGroupsExist(r.client, asgPaths)...)
+}
+
+func (r *cfsecurityDefaultAsgsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// apply enable security groups given in plan and disable the others
+func (r *cfsecurityDefaultAsgsResource) apply(ctx context.Context, plan cfsecurityDefaultAsgsResourceModel) diag.Diagnostics {
+	diags := checkCurrentUserIsAdmin(r.client)
+	if diags.HasError() {
+		return diags
+	}
+
+	var running, staging []string
+	plan.Running.ElementsAs(ctx, &running, false)
+	plan.Staging.ElementsAs(ctx, &staging, false)
+
+	secGroups, _, err := r.session.V3().GetSecurityGroups()
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get security groups : %s", err),
+		)
+		return diags
+	}
+
+	for _, guid := range append(append([]string{}, running...), staging...) {
+		if !isInSlice(secGroups, func(object interface{}) bool {
+			return object.(resources.SecurityGroup).GUID == guid
+		}) {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Security group %s not found", guid),
+			)
+			return diags
+		}
+	}
+
+	for _, update := range defaultAsgsUpdates(secGroups, running, staging) {
+		_, _, err := r.session.V3().UpdateSecurityGroup(update)
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to update security group %s, got error: %s", update.Name, err),
+			)
+			return diags
+		}
+	}
+	return diags
+}
+
+// defaultAsgsUpdates return updates of security groups which are not globally enabled as wanted
+// for running and staging lifecycles, security groups already as wanted are left out
+func defaultAsgsUpdates(secGroups []resources.SecurityGroup, running []string, staging []string) []resources.SecurityGroup {
+	updates := make([]resources.SecurityGroup, 0)
+	for _, secGroup := range secGroups {
+		wantRunning := funk.ContainsString(running, secGroup.GUID)
+		wantStaging := funk.ContainsString(staging, secGroup.GUID)
+		isRunning := secGroup.RunningGloballyEnabled != nil && *secGroup.RunningGloballyEnabled
+		isStaging := secGroup.StagingGloballyEnabled != nil && *secGroup.StagingGloballyEnabled
+		if wantRunning == isRunning && wantStaging == isStaging {
+			continue
+		}
+		updates = append(updates, resources.SecurityGroup{
+			GUID:                   secGroup.GUID,
+			Name:                   secGroup.Name,
+			RunningGloballyEnabled: &wantRunning,
+			StagingGloballyEnabled: &wantStaging,
+		})
+	}
+	return updates
+}
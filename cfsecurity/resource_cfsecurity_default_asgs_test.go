@@ -0,0 +1,73 @@
+package cfsecurity
+
+import (
+	"testing"
+
+	"code.cloudfoundry.org/cli/v8/resources"
+)
+
+func TestDefaultAsgsUpdates(t *testing.T) {
+	enabled, disabled := true, false
+	secGroups := []resources.SecurityGroup{
+		{GUID: "asg-none", Name: "none"},
+		{GUID: "asg-running", Name: "running", RunningGloballyEnabled: &enabled, StagingGloballyEnabled: &disabled},
+		{GUID: "asg-staging", Name: "staging", StagingGloballyEnabled: &enabled},
+		{GUID: "asg-both", Name: "both", RunningGloballyEnabled: &enabled, StagingGloballyEnabled: &enabled},
+	}
+
+	tests := []struct {
+		name    string
+		running []string
+		staging []string
+		// want is the running and staging flags of each updated security group
+		want map[string][2]bool
+	}{
+		{
+			name:    "nothing changes",
+			running: []string{"asg-running", "asg-both"},
+			staging: []string{"asg-staging", "asg-both"},
+			want:    map[string][2]bool{},
+		},
+		{
+			name:    "enable",
+			running: []string{"asg-none", "asg-running", "asg-both"},
+			staging: []string{"asg-running", "asg-staging", "asg-both"},
+			want:    map[string][2]bool{"asg-none": {true, false}, "asg-running": {true, true}},
+		},
+		{
+			name:    "disable",
+			running: []string{"asg-running"},
+			staging: []string{"asg-both"},
+			want:    map[string][2]bool{"asg-staging": {false, false}, "asg-both": {false, true}},
+		},
+		{
+			name: "disable everything",
+			want: map[string][2]bool{"asg-running": {false, false}, "asg-staging": {false, false}, "asg-both": {false, false}},
+		},
+		{
+			name:    "switch lifecycle",
+			running: []string{"asg-staging", "asg-both"},
+			staging: []string{"asg-running", "asg-both"},
+			want:    map[string][2]bool{"asg-running": {false, true}, "asg-staging": {true, false}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates := defaultAsgsUpdates(secGroups, tt.running, tt.staging)
+			if len(updates) != len(tt.want) {
+				t.Fatalf("got %d updates, want %d: %+v", len(updates), len(tt.want), updates)
+			}
+			for _, update := range updates {
+				want, ok := tt.want[update.GUID]
+				if !ok {
+					t.Errorf("unexpected update of %s", update.GUID)
+					continue
+				}
+				if *update.RunningGloballyEnabled != want[0] || *update.StagingGloballyEnabled != want[1] {
+					t.Errorf("got running %v staging %v for %s, want running %v staging %v",
+						*update.RunningGloballyEnabled, *update.StagingGloballyEnabled, update.GUID, want[0], want[1])
+				}
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
 )
@@ -25,6 +26,7 @@ var _ resource.Resource = &cfsecurityEntitleAsgResource{}
 var _ resource.ResourceWithConfigure = &cfsecurityEntitleAsgResource{}
 var _ resource.ResourceWithImportState = &cfsecurityEntitleAsgResource{}
 var _ resource.ResourceWithValidateConfig = &cfsecurityEntitleAsgResource{}
+var _ resource.ResourceWithModifyPlan = &cfsecurityEntitleAsgResource{}
 
 func NewCFSecurityEntitleAsgResource(config *clients.Config) resource.Resource {
 	return &cfsecurityEntitleAsgResource{
@@ -64,8 +66,19 @@ func (r *cfsecurityEntitleAsgResource) Configure(_ context.Context, req resource
 	r.client = clt
 }
 
+// entitleAsgDeprecationMessage explain how to migrate away from cfsecurity_entitle_asg
+const entitleAsgDeprecationMessage = "cfsecurity_entitle_asg does nothing since entitlements have been removed from cfsecurity server, " +
+	"use cfsecurity_bind_asg instead: a moved block from cfsecurity_entitle_asg to cfsecurity_bind_asg converts " +
+	"each entitlement into bindings of the security group to every space of the org."
+
 func (r *cfsecurityEntitleAsgResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = schema.Schema{
+	resp.Schema = entitleAsgSchema()
+}
+
+// entitleAsgSchema return schema of cfsecurity_entitle_asg, it is also the source schema when moving to cfsecurity_bind_asg
+func entitleAsgSchema() schema.Schema {
+	return schema.Schema{
+		DeprecationMessage: entitleAsgDeprecationMessage,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -76,15 +89,22 @@ func (r *cfsecurityEntitleAsgResource) Schema(_ context.Context, _ resource.Sche
 		},
 		Blocks: map[string]schema.Block{
 			"entitle": schema.SetNestedBlock{
+				DeprecationMessage: entitleAsgDeprecationMessage,
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
 						"asg_id": schema.StringAttribute{
 							Description: "The security group guid",
 							Required:    true,
+							Validators: []validator.String{
+								guidValidator{},
+							},
 						},
 						"org_id": schema.StringAttribute{
 							Description: "The org guid",
 							Required:    true,
+							Validators: []validator.String{
+								guidValidator{},
+							},
 						},
 					},
 				},
@@ -134,6 +154,15 @@ func (r *cfsecurityEntitleAsgResource) Delete(context.Context, resource.DeleteRe
 
 }
 
+// ModifyPlan warn on every plan that the resource does nothing
+func (r *cfsecurityEntitleAsgResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to warn about on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	resp.Diagnostics.AddWarning("Deprecated Resource", entitleAsgDeprecationMessage)
+}
+
 func (r *cfsecurityEntitleAsgResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
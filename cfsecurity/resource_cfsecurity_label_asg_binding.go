@@ -0,0 +1,201 @@
+package cfsecurity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3"
+	clients "github.com/cloudfoundry-community/go-cf-clients-helper/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+)
+
+type cfsecurityLabelAsgBindingResource struct {
+	asgBindingResource
+}
+
+var _ resource.Resource = &cfsecurityLabelAsgBindingResource{}
+var _ resource.ResourceWithConfigure = &cfsecurityLabelAsgBindingResource{}
+var _ resource.ResourceWithModifyPlan = &cfsecurityLabelAsgBindingResource{}
+var _ resource.ResourceWithValidateConfig = &cfsecurityLabelAsgBindingResource{}
+var _ resource.ResourceWithIdentity = &cfsecurityLabelAsgBindingResource{}
+var _ resource.ResourceWithImportState = &cfsecurityLabelAsgBindingResource{}
+
+func NewCFSecurityLabelAsgBindingResource(config *clients.Config, session *clients.Session, options providerOptions) resource.Resource {
+	r := &cfsecurityLabelAsgBindingResource{
+		asgBindingResource: asgBindingResource{
+			config:  config,
+			session: session,
+			options: options,
+		},
+	}
+	r.selector = r
+	return r
+}
+
+type cfsecurityLabelAsgBindingResourceModel struct {
+	asgBindingResourceModel
+	LabelSelector types.String `tfsdk:"label_selector"`
+	OrgID         types.String `tfsdk:"org_id"`
+}
+
+// cfsecurityLabelAsgBindingResourceIdentityModel identify bindings of the resource by label selector, org, security groups and lifecycle
+type cfsecurityLabelAsgBindingResourceIdentityModel struct {
+	asgBindingResourceIdentityModel
+	LabelSelector types.String `tfsdk:"label_selector"`
+	OrgGUID       types.String `tfsdk:"org_guid"`
+}
+
+func (r *cfsecurityLabelAsgBindingResource) typeName() string {
+	return "label_asg_binding"
+}
+
+func (r *cfsecurityLabelAsgBindingResource) targetDescription() string {
+	return "every matching space"
+}
+
+func (r *cfsecurityLabelAsgBindingResource) selectorAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"label_selector": schema.StringAttribute{
+			Description: "Cloud foundry label selector matching spaces to bind (e.g.: tier=prod,network-zone in (dmz))",
+			Required:    true,
+		},
+		"org_id": schema.StringAttribute{
+			Description: "The org guid to look for spaces in, every org managed by the user is used when not set",
+			Optional:    true,
+			Validators: []validator.String{
+				guidValidator{},
+			},
+		},
+	}
+}
+
+func (r *cfsecurityLabelAsgBindingResource) selectorIdentityAttributes() map[string]identityschema.Attribute {
+	return map[string]identityschema.Attribute{
+		"label_selector": identityschema.StringAttribute{
+			Description:       "Label selector matching spaces",
+			RequiredForImport: true,
+		},
+		"org_guid": identityschema.StringAttribute{
+			Description:       "Guid of the org to look for spaces in, every org managed by the user when not set",
+			OptionalForImport: true,
+		},
+	}
+}
+
+func (r *cfsecurityLabelAsgBindingResource) newModel() asgBindingModel {
+	return &cfsecurityLabelAsgBindingResourceModel{}
+}
+
+func (r *cfsecurityLabelAsgBindingResource) newIdentity(dataModel asgBindingModel, identity asgBindingResourceIdentityModel) interface{} {
+	data := dataModel.(*cfsecurityLabelAsgBindingResourceModel)
+	return cfsecurityLabelAsgBindingResourceIdentityModel{
+		asgBindingResourceIdentityModel: identity,
+		LabelSelector:                   data.LabelSelector,
+		OrgGUID:                         data.OrgID,
+	}
+}
+
+func (r *cfsecurityLabelAsgBindingResource) importModel(ctx context.Context, resourceIdentity *tfsdk.ResourceIdentity) (asgBindingModel, asgBindingResourceIdentityModel, diag.Diagnostics) {
+	var identity cfsecurityLabelAsgBindingResourceIdentityModel
+	diags := resourceIdentity.Get(ctx, &identity)
+	if diags.HasError() {
+		return nil, identity.asgBindingResourceIdentityModel, diags
+	}
+	if identity.LabelSelector.ValueString() == "" {
+		diags.AddAttributeError(path.Root("label_selector"), "Invalid Identity", "\"label_selector\" must not be empty.")
+	}
+	if !identity.OrgGUID.IsNull() {
+		diags.Append(checkIdentityGUIDs("org_guid", identity.OrgGUID.ValueString())...)
+	}
+
+	return &cfsecurityLabelAsgBindingResourceModel{
+		LabelSelector: identity.LabelSelector,
+		OrgID:         identity.OrgGUID,
+	}, identity.asgBindingResourceIdentityModel, diags
+}
+
+func (r *cfsecurityLabelAsgBindingResource) validateSelector(_ context.Context, dataModel asgBindingModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	data := dataModel.(*cfsecurityLabelAsgBindingResourceModel)
+
+	if !data.LabelSelector.IsUnknown() && strings.TrimSpace(data.LabelSelector.ValueString()) == "" {
+		diags.AddAttributeError(path.Root("label_selector"), "Attribute Error", "\"label_selector\" must not be empty.")
+	}
+	return diags
+}
+
+func (r *cfsecurityLabelAsgBindingResource) selectorUnknown(dataModel asgBindingModel) bool {
+	data := dataModel.(*cfsecurityLabelAsgBindingResourceModel)
+	return data.LabelSelector.IsUnknown() || data.OrgID.IsUnknown()
+}
+
+func (r *cfsecurityLabelAsgBindingResource) checkSelector(_ context.Context, dataModel asgBindingModel) diag.Diagnostics {
+	data := dataModel.(*cfsecurityLabelAsgBindingResourceModel)
+	orgPaths := make(map[string][]path.Path)
+	addGUIDPath(orgPaths, data.OrgID, path.Root("org_id"))
+	return checkOrgsExist(r.session.V3(), orgPaths)
+}
+
+// selectSpaces return spaces matching the label selector in the org, or in every org managed by the user
+// when no org is given (admins are not restricted to managed orgs)
+func (r *cfsecurityLabelAsgBindingResource) selectSpaces(_ context.Context, dataModel asgBindingModel) ([]client.Space, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	data := dataModel.(*cfsecurityLabelAsgBindingResourceModel)
+
+	spaces, err := r.getLabelSpaces(data)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get spaces matching %s: %s", data.LabelSelector.ValueString(), err),
+		)
+		return nil, diags
+	}
+	return spaces, diags
+}
+
+func (r *cfsecurityLabelAsgBindingResource) getLabelSpaces(data *cfsecurityLabelAsgBindingResourceModel) ([]client.Space, error) {
+	labelQuery := ccv3.Query{Key: ccv3.LabelSelectorFilter, Values: []string{data.LabelSelector.ValueString()}}
+
+	var orgGUIDs []string
+	if !data.OrgID.IsNull() {
+		orgGUIDs = []string{data.OrgID.ValueString()}
+	} else {
+		userIsAdmin, err := r.client.CurrentUserIsAdmin()
+		if err != nil {
+			return nil, err
+		}
+		if userIsAdmin {
+			return getSpaces(r.client, []ccv3.Query{labelQuery})
+		}
+		claims, err := getClaimsFromToken(*r.client.GetAccessToken())
+		if err != nil {
+			return nil, err
+		}
+		orgGUIDs, _, err = getUserManagedGUIDs(r.session.V3(), claims.Sub)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	spaces := make([]client.Space, 0)
+	for _, chunk := range chunkStrings(orgGUIDs, 50) {
+		chunkSpaces, err := getSpaces(r.client, []ccv3.Query{
+			labelQuery,
+			{Key: ccv3.OrganizationGUIDFilter, Values: chunk},
+		})
+		if err != nil {
+			return nil, err
+		}
+		spaces = append(spaces, chunkSpaces...)
+	}
+	return spaces, nil
+}
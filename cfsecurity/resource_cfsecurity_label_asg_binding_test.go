@@ -0,0 +1,73 @@
+package cfsecurity
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestLabelSelectSpaces(t *testing.T) {
+	adminToken := "bearer." + base64.RawStdEncoding.EncodeToString([]byte(`{"scope":["cloud_controller.admin"]}`)) + ".signature"
+
+	tests := []struct {
+		name      string
+		orgID     types.String
+		wantQuery map[string]string
+	}{
+		{
+			name:      "spaces of the org",
+			orgID:     types.StringValue("org-1"),
+			wantQuery: map[string]string{"label_selector": "env=prod", "organization_guids": "org-1"},
+		},
+		{
+			name:      "spaces of every org for admins",
+			orgID:     types.StringNull(),
+			wantQuery: map[string]string{"label_selector": "env=prod", "organization_guids": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []map[string]string
+			clt := newTestClient(t, adminToken, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v3/spaces" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				queries = append(queries, map[string]string{
+					"label_selector":     r.URL.Query().Get("label_selector"),
+					"organization_guids": r.URL.Query().Get("organization_guids"),
+				})
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"pagination":{"next":null},"resources":[
+					{"guid":"space-1","name":"api"},
+					{"guid":"space-2","name":"worker"}
+				]}`))
+			})
+			r := &cfsecurityLabelAsgBindingResource{asgBindingResource: asgBindingResource{client: clt}}
+
+			data := &cfsecurityLabelAsgBindingResourceModel{
+				LabelSelector: types.StringValue("env=prod"),
+				OrgID:         tt.orgID,
+			}
+			spaces, diags := r.selectSpaces(context.Background(), data)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if len(queries) != 1 {
+				t.Fatalf("got %d requests for spaces, want 1", len(queries))
+			}
+			for key, want := range tt.wantQuery {
+				if got := queries[0][key]; got != want {
+					t.Errorf("got %s=%q, want %q", key, got, want)
+				}
+			}
+			if got := strings.Join(spaceGUIDs(spaces), ","); got != "space-1,space-2" {
+				t.Errorf("got spaces %s, want space-1,space-2", got)
+			}
+		})
+	}
+}
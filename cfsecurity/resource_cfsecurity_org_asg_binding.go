@@ -0,0 +1,178 @@
+package cfsecurity
+
+import (
+	"context"
+	"fmt"
+	pathpkg "path"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3"
+	clients "github.com/cloudfoundry-community/go-cf-clients-helper/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+)
+
+type cfsecurityOrgAsgBindingResource struct {
+	asgBindingResource
+}
+
+var _ resource.Resource = &cfsecurityOrgAsgBindingResource{}
+var _ resource.ResourceWithConfigure = &cfsecurityOrgAsgBindingResource{}
+var _ resource.ResourceWithModifyPlan = &cfsecurityOrgAsgBindingResource{}
+var _ resource.ResourceWithValidateConfig = &cfsecurityOrgAsgBindingResource{}
+var _ resource.ResourceWithIdentity = &cfsecurityOrgAsgBindingResource{}
+var _ resource.ResourceWithImportState = &cfsecurityOrgAsgBindingResource{}
+
+func NewCFSecurityOrgAsgBindingResource(config *clients.Config, session *clients.Session, options providerOptions) resource.Resource {
+	r := &cfsecurityOrgAsgBindingResource{
+		asgBindingResource: asgBindingResource{
+			config:  config,
+			session: session,
+			options: options,
+		},
+	}
+	r.selector = r
+	return r
+}
+
+type cfsecurityOrgAsgBindingResourceModel struct {
+	asgBindingResourceModel
+	OrgID         types.String `tfsdk:"org_id"`
+	IncludeSpaces types.Set    `tfsdk:"include_spaces"`
+	ExcludeSpaces types.Set    `tfsdk:"exclude_spaces"`
+}
+
+// cfsecurityOrgAsgBindingResourceIdentityModel identify bindings of the resource by org, security groups and lifecycle
+type cfsecurityOrgAsgBindingResourceIdentityModel struct {
+	asgBindingResourceIdentityModel
+	OrgGUID types.String `tfsdk:"org_guid"`
+}
+
+func (r *cfsecurityOrgAsgBindingResource) typeName() string {
+	return "org_asg_binding"
+}
+
+func (r *cfsecurityOrgAsgBindingResource) targetDescription() string {
+	return "every space of the org"
+}
+
+func (r *cfsecurityOrgAsgBindingResource) selectorAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"org_id": schema.StringAttribute{
+			Description: "The org guid",
+			Required:    true,
+			Validators: []validator.String{
+				guidValidator{},
+			},
+		},
+		"include_spaces": schema.SetAttribute{
+			Description: "Only bind spaces with a name matching one of these patterns (e.g.: prod-*)",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"exclude_spaces": schema.SetAttribute{
+			Description: "Do not bind spaces with a name matching one of these patterns (e.g.: sandbox-*)",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (r *cfsecurityOrgAsgBindingResource) selectorIdentityAttributes() map[string]identityschema.Attribute {
+	return map[string]identityschema.Attribute{
+		"org_guid": identityschema.StringAttribute{
+			Description:       "Guid of the org",
+			RequiredForImport: true,
+		},
+	}
+}
+
+func (r *cfsecurityOrgAsgBindingResource) newModel() asgBindingModel {
+	return &cfsecurityOrgAsgBindingResourceModel{}
+}
+
+func (r *cfsecurityOrgAsgBindingResource) newIdentity(dataModel asgBindingModel, identity asgBindingResourceIdentityModel) interface{} {
+	data := dataModel.(*cfsecurityOrgAsgBindingResourceModel)
+	return cfsecurityOrgAsgBindingResourceIdentityModel{
+		asgBindingResourceIdentityModel: identity,
+		OrgGUID:                         data.OrgID,
+	}
+}
+
+func (r *cfsecurityOrgAsgBindingResource) importModel(ctx context.Context, resourceIdentity *tfsdk.ResourceIdentity) (asgBindingModel, asgBindingResourceIdentityModel, diag.Diagnostics) {
+	var identity cfsecurityOrgAsgBindingResourceIdentityModel
+	diags := resourceIdentity.Get(ctx, &identity)
+	if diags.HasError() {
+		return nil, identity.asgBindingResourceIdentityModel, diags
+	}
+	diags.Append(checkIdentityGUIDs("org_guid", identity.OrgGUID.ValueString())...)
+
+	return &cfsecurityOrgAsgBindingResourceModel{
+		OrgID:         identity.OrgGUID,
+		IncludeSpaces: types.SetNull(types.StringType),
+		ExcludeSpaces: types.SetNull(types.StringType),
+	}, identity.asgBindingResourceIdentityModel, diags
+}
+
+func (r *cfsecurityOrgAsgBindingResource) validateSelector(ctx context.Context, dataModel asgBindingModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	data := dataModel.(*cfsecurityOrgAsgBindingResourceModel)
+
+	for attr, patternsSet := range map[string]types.Set{"include_spaces": data.IncludeSpaces, "exclude_spaces": data.ExcludeSpaces} {
+		var patterns []types.String
+		patternsSet.ElementsAs(ctx, &patterns, false)
+		for _, pattern := range patterns {
+			if pattern.IsUnknown() || pattern.IsNull() {
+				continue
+			}
+			if _, err := pathpkg.Match(pattern.ValueString(), ""); err != nil {
+				diags.AddAttributeError(path.Root(attr), "Attribute Error", fmt.Sprintf("invalid pattern %q: %s", pattern.ValueString(), err))
+			}
+		}
+	}
+	return diags
+}
+
+func (r *cfsecurityOrgAsgBindingResource) selectorUnknown(dataModel asgBindingModel) bool {
+	data := dataModel.(*cfsecurityOrgAsgBindingResourceModel)
+	return data.OrgID.IsUnknown() || data.IncludeSpaces.IsUnknown() || data.ExcludeSpaces.IsUnknown()
+}
+
+func (r *cfsecurityOrgAsgBindingResource) checkSelector(_ context.Context, dataModel asgBindingModel) diag.Diagnostics {
+	data := dataModel.(*cfsecurityOrgAsgBindingResourceModel)
+	orgPaths := make(map[string][]path.Path)
+	addGUIDPath(orgPaths, data.OrgID, path.Root("org_id"))
+	return checkOrgsExist(r.session.V3(), orgPaths)
+}
+
+// selectSpaces return spaces of the org matching include and exclude patterns
+func (r *cfsecurityOrgAsgBindingResource) selectSpaces(ctx context.Context, dataModel asgBindingModel) ([]client.Space, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	data := dataModel.(*cfsecurityOrgAsgBindingResourceModel)
+
+	var include, exclude []string
+	data.IncludeSpaces.ElementsAs(ctx, &include, false)
+	data.ExcludeSpaces.ElementsAs(ctx, &exclude, false)
+
+	spaces, err := getSpaces(r.client, []ccv3.Query{{Key: ccv3.OrganizationGUIDFilter, Values: []string{data.OrgID.ValueString()}}})
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get spaces of org %s: %s", data.OrgID.ValueString(), err),
+		)
+		return nil, diags
+	}
+	selected := make([]client.Space, 0)
+	for _, space := range spaces {
+		if matchNamePatterns(space.Name, include, exclude) {
+			selected = append(selected, space)
+		}
+	}
+	return selected, diags
+}
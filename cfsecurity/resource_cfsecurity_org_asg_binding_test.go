@@ -0,0 +1,71 @@
+package cfsecurity
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOrgSelectSpaces(t *testing.T) {
+	var orgGUIDs []string
+	clt := newTestClient(t, "", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/spaces" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		orgGUIDs = append(orgGUIDs, r.URL.Query().Get("organization_guids"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"pagination":{"next":null},"resources":[
+			{"guid":"space-1","name":"prod-api"},
+			{"guid":"space-2","name":"prod-sandbox"},
+			{"guid":"space-3","name":"dev"}
+		]}`))
+	})
+	r := &cfsecurityOrgAsgBindingResource{asgBindingResource: asgBindingResource{client: clt}}
+
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		want    []string
+	}{
+		{name: "every space", want: []string{"space-1", "space-2", "space-3"}},
+		{name: "included spaces", include: []string{"prod-*"}, want: []string{"space-1", "space-2"}},
+		{name: "excluded spaces", exclude: []string{"prod-sandbox"}, want: []string{"space-1", "space-3"}},
+		{name: "included and excluded spaces", include: []string{"prod-*"}, exclude: []string{"*-sandbox"}, want: []string{"space-1"}},
+		{name: "no space matching", include: []string{"staging-*"}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgGUIDs = nil
+			ctx := context.Background()
+			include, diags := types.SetValueFrom(ctx, types.StringType, tt.include)
+			exclude, excludeDiags := types.SetValueFrom(ctx, types.StringType, tt.exclude)
+			diags.Append(excludeDiags...)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			data := &cfsecurityOrgAsgBindingResourceModel{
+				OrgID:         types.StringValue("org-1"),
+				IncludeSpaces: include,
+				ExcludeSpaces: exclude,
+			}
+			spaces, diags := r.selectSpaces(ctx, data)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if strings.Join(orgGUIDs, ",") != "org-1" {
+				t.Errorf("got spaces of orgs %v, want org-1", orgGUIDs)
+			}
+			got := spaceGUIDs(spaces)
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got spaces %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,710 @@
+package cfsecurity
+
+import (
+	"context"
+	"fmt"
+	pathpkg "path"
+	"sort"
+
+	clients "github.com/cloudfoundry-community/go-cf-clients-helper/v2"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+	"github.com/thoas/go-funk"
+)
+
+// asgBindingResource is the implementation shared by resources binding security groups to a selection of spaces
+// (cfsecurity_org_asg_binding, cfsecurity_label_asg_binding), each of them only gives its way to select spaces
+type asgBindingResource struct {
+	client   *client.Client
+	config   *clients.Config
+	session  *clients.Session
+	options  providerOptions
+	selector spaceSelector
+}
+
+// importedKey is the private state key telling that the resource has just been imported, its selected spaces
+// where security groups are already bound are then taken by the following read
+const importedKey = "imported"
+
+// spaceSelector select spaces a resource binds security groups to, with the attributes it needs to do so
+type spaceSelector interface {
+	// typeName is the resource type name without provider prefix
+	typeName() string
+	// targetDescription describe spaces targeted by the resource (e.g.: every space of the org)
+	targetDescription() string
+	selectorAttributes() map[string]schema.Attribute
+	selectorIdentityAttributes() map[string]identityschema.Attribute
+	newModel() asgBindingModel
+	// newIdentity return identity of the resource from its model and the security groups and lifecycle part of the identity
+	newIdentity(data asgBindingModel, identity asgBindingResourceIdentityModel) interface{}
+	// importModel return model of the resource from an identity, only selector attributes are set, with the
+	// security groups and lifecycle part of the identity
+	importModel(ctx context.Context, identity *tfsdk.ResourceIdentity) (asgBindingModel, asgBindingResourceIdentityModel, diag.Diagnostics)
+	validateSelector(ctx context.Context, data asgBindingModel) diag.Diagnostics
+	// selectorUnknown return true when spaces can not be selected yet because attributes selecting them are unknown
+	selectorUnknown(data asgBindingModel) bool
+	// checkSelector check that objects referenced by attributes selecting spaces exist
+	checkSelector(ctx context.Context, data asgBindingModel) diag.Diagnostics
+	selectSpaces(ctx context.Context, data asgBindingModel) ([]client.Space, diag.Diagnostics)
+}
+
+// asgBindingResourceModel holds attributes shared by resources binding security groups to a selection of spaces,
+// models of these resources embed it
+type asgBindingResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	Asgs            types.Set    `tfsdk:"asgs"`
+	AsgNamePattern  types.String `tfsdk:"asg_name_pattern"`
+	Lifecycle       types.String `tfsdk:"lifecycle"`
+	Spaces          types.Set    `tfsdk:"spaces"`
+	AllowDisruption types.Bool   `tfsdk:"allow_disruption"`
+	DisruptedApps   types.Set    `tfsdk:"disrupted_apps"`
+	ExpiresAt       types.String `tfsdk:"expires_at"`
+	Expired         types.Bool   `tfsdk:"expired"`
+}
+
+// asgBindingModel is implemented by pointers to models embedding asgBindingResourceModel
+type asgBindingModel interface {
+	binding() *asgBindingResourceModel
+}
+
+func (m *asgBindingResourceModel) binding() *asgBindingResourceModel {
+	return m
+}
+
+// asgBindingResourceIdentityModel holds identity attributes shared by resources binding security groups to a selection of spaces
+type asgBindingResourceIdentityModel struct {
+	AsgGUIDs  []string     `tfsdk:"asg_guids"`
+	Lifecycle types.String `tfsdk:"lifecycle"`
+}
+
+func (r *asgBindingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.selector.typeName()
+	// identity follows security groups and lifecycle, which change on update
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+func (r *asgBindingResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	attributes := map[string]identityschema.Attribute{
+		"asg_guids": identityschema.ListAttribute{
+			Description:       "Guids of the security groups bound",
+			ElementType:       types.StringType,
+			RequiredForImport: true,
+		},
+		"lifecycle": identityschema.StringAttribute{
+			Description:       "Lifecycle security groups are bound to, either running or staging, both when not set",
+			OptionalForImport: true,
+		},
+	}
+	for name, attribute := range r.selector.selectorIdentityAttributes() {
+		attributes[name] = attribute
+	}
+	resp.IdentitySchema = identityschema.Schema{Attributes: attributes}
+}
+
+func (r *asgBindingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clt, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = clt
+}
+
+func (r *asgBindingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"asgs": schema.SetAttribute{
+			Description: fmt.Sprintf("Guids of the security groups to bind to %s, asgs or asg_name_pattern must be given", r.selector.targetDescription()),
+			Optional:    true,
+			Computed:    true,
+			ElementType: types.StringType,
+			Validators: []validator.Set{
+				guidSetValidator{},
+			},
+		},
+		"asg_name_pattern": schema.StringAttribute{
+			Description: "Bind security groups with a name matching this pattern (e.g.: platform-egress-*), resolved guids are set in asgs",
+			Optional:    true,
+		},
+		"lifecycle": schema.StringAttribute{
+			Description: "Lifecycle to bind security groups to, either running or staging (default: both)",
+			Optional:    true,
+		},
+		"allow_disruption": allowDisruptionAttribute(),
+		"disrupted_apps":   disruptedAppsAttribute(),
+		"expires_at":       expiresAtAttribute(),
+		"expired":          expiredAttribute(),
+		"spaces": schema.SetAttribute{
+			Description: "Guids of the spaces where security groups are bound",
+			Computed:    true,
+			ElementType: types.StringType,
+		},
+	}
+	for name, attribute := range r.selector.selectorAttributes() {
+		attributes[name] = attribute
+	}
+	resp.Schema = schema.Schema{Attributes: attributes}
+}
+
+func (r *asgBindingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	planModel := r.selector.newModel()
+	plan := planModel.binding()
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, planModel)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := refreshTokenIfExpired(r.client, r.config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to refresh token: %s", err),
+		)
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to generate uuid: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.resolvePlan(ctx, planModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, add := getListBindChanges(nil, asgBindingBinds(ctx, plan))
+	for _, aBind := range add {
+		err := bindSecurityGroupForLifecycle(r.client, aBind.AsgID.ValueString(), aBind.SpaceID.ValueString(), plan.Lifecycle.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to bind security group, got error: %s", err),
+			)
+			return
+		}
+	}
+
+	disruptedApps, aErr := appliedDisruptedApps(ctx, plan.DisruptedApps, nil)
+	resp.Diagnostics.Append(aErr...)
+	plan.DisruptedApps = disruptedApps
+
+	plan.Id = types.StringValue(id)
+	resp.Diagnostics.Append(resp.State.Set(ctx, planModel)...)
+	resp.Diagnostics.Append(r.setIdentity(ctx, resp.Identity, planModel)...)
+}
+
+func (r *asgBindingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	stateModel := r.selector.newModel()
+	state := stateModel.binding()
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, stateModel)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkIdentity(ctx, req.Identity, r.identity(ctx, stateModel))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := refreshTokenIfExpired(r.client, r.config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to refresh token: %s", err),
+		)
+		return
+	}
+
+	// expired is refreshed from expires_at, spaces still bound are unbound by next apply
+	state.Expired = types.BoolValue(isExpired(state.ExpiresAt))
+
+	var asgs, stateSpaces []string
+	state.Asgs.ElementsAs(ctx, &asgs, false)
+	state.Spaces.ElementsAs(ctx, &stateSpaces, false)
+
+	// only spaces in state are checked, selected spaces not bound yet show as to be bound in the next plan and spaces
+	// bound by another way are never taken, except when importing where they are adopted
+	imported, diags := req.Private.GetKey(ctx, importedKey)
+	resp.Diagnostics.Append(diags...)
+	if len(imported) > 0 {
+		selected, diags := r.selector.selectSpaces(ctx, stateModel)
+		resp.Diagnostics.Append(diags...)
+		stateSpaces = append(stateSpaces, spaceGUIDs(selected)...)
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedKey, nil)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// spaces missing a binding are removed from state, next plan will show them as to be bound
+	boundSpaces, err := getBoundSpaces(r.client, asgs, stateSpaces, state.Lifecycle.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get security groups : %s", err),
+		)
+		return
+	}
+	if state.Expired.ValueBool() && len(boundSpaces) > 0 {
+		addExpiredWarning(&resp.Diagnostics, state.Id.ValueString(), state.ExpiresAt)
+	}
+
+	spaces, aErr := types.SetValueFrom(ctx, types.StringType, boundSpaces)
+	if aErr.HasError() {
+		resp.Diagnostics.Append(aErr...)
+		return
+	}
+	state.Spaces = spaces
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, stateModel)...)
+	resp.Diagnostics.Append(r.setIdentity(ctx, resp.Identity, stateModel)...)
+}
+
+func (r *asgBindingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	planModel, stateModel := r.selector.newModel(), r.selector.newModel()
+	plan, state := planModel.binding(), stateModel.binding()
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, planModel)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, stateModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := refreshTokenIfExpired(r.client, r.config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to refresh token: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.resolvePlan(ctx, planModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remove, add := getListBindChanges(asgBindingBinds(ctx, state), asgBindingBinds(ctx, plan))
+	if plan.Lifecycle.ValueString() != state.Lifecycle.ValueString() {
+		remove, add = asgBindingBinds(ctx, state), asgBindingBinds(ctx, plan)
+	}
+
+	// apps may have been started since plan, check again before unbinding
+	disrupted, diags := checkDisruption(r.session.V3(), disruptiveRemovedBinds(asgBindingBinds(ctx, state), asgBindingBinds(ctx, plan), state.Lifecycle.ValueString(), plan.Lifecycle.ValueString()), disruptionAllowed(plan.AllowDisruption, plan.Expired.ValueBool()))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	disruptedApps, aErr := appliedDisruptedApps(ctx, plan.DisruptedApps, disrupted)
+	resp.Diagnostics.Append(aErr...)
+	plan.DisruptedApps = disruptedApps
+
+	for _, rBind := range remove {
+		err := unbindSecurityGroupForLifecycle(r.client, r.session.V3(), rBind.AsgID.ValueString(), rBind.SpaceID.ValueString(), state.Lifecycle.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to unbind security group, got error: %s", err),
+			)
+			return
+		}
+	}
+	for _, aBind := range add {
+		err := bindSecurityGroupForLifecycle(r.client, aBind.AsgID.ValueString(), aBind.SpaceID.ValueString(), plan.Lifecycle.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to bind security group, got error: %s", err),
+			)
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, planModel)...)
+	resp.Diagnostics.Append(r.setIdentity(ctx, resp.Identity, planModel)...)
+}
+
+func (r *asgBindingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	stateModel := r.selector.newModel()
+	state := stateModel.binding()
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, stateModel)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := refreshTokenIfExpired(r.client, r.config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to refresh token: %s", err),
+		)
+		return
+	}
+
+	_, diags := checkDisruption(r.session.V3(), asgBindingBinds(ctx, state), disruptionAllowed(state.AllowDisruption, isExpired(state.ExpiresAt)))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, rBind := range asgBindingBinds(ctx, state) {
+		err := unbindSecurityGroupForLifecycle(r.client, r.session.V3(), rBind.AsgID.ValueString(), rBind.SpaceID.ValueString(), state.Lifecycle.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to unbind security group, got error: %s", err),
+			)
+			return
+		}
+	}
+}
+
+// ModifyPlan check that objects selecting spaces and security groups exist, report security groups which have no effect,
+// select spaces at plan time, this make spaces entering or leaving the selection appear as changes in the plan,
+// then check that the current user can bind security groups to spaces where bindings change
+func (r *asgBindingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check or resolve when provider is not yet configured
+	if r.client == nil {
+		return
+	}
+
+	// on destroy, only check apps disrupted by unbinding everything
+	if req.Plan.Raw.IsNull() {
+		stateModel := r.selector.newModel()
+		resp.Diagnostics.Append(req.State.Get(ctx, stateModel)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		err := refreshTokenIfExpired(r.client, r.config)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to refresh token: %s", err),
+			)
+			return
+		}
+		state := stateModel.binding()
+		_, diags := checkDisruption(r.session.V3(), asgBindingBinds(ctx, state), disruptionAllowed(state.AllowDisruption, isExpired(state.ExpiresAt)))
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	planModel := r.selector.newModel()
+	plan := planModel.binding()
+	resp.Diagnostics.Append(req.Plan.Get(ctx, planModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := refreshTokenIfExpired(r.client, r.config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to refresh token: %s", err),
+		)
+		return
+	}
+
+	stateModel := r.selector.newModel()
+	state := stateModel.binding()
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, stateModel)...)
+	}
+	resp.Diagnostics.Append(checkExpiresAt(r.options, plan.ExpiresAt, state.ExpiresAt, req.State.Raw.IsNull())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	expired := setPlanExpired(ctx, resp, plan.ExpiresAt)
+
+	// security groups resolved from asg_name_pattern always exist
+	asgPaths := make(map[string][]path.Path)
+	if plan.AsgNamePattern.IsNull() {
+		addSetGUIDPaths(asgPaths, plan.Asgs, path.Root("asgs"))
+	}
+	resp.Diagnostics.Append(checkSecurityGroupsExist(r.client, asgPaths)...)
+	resp.Diagnostics.Append(r.selector.checkSelector(ctx, planModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.AsgNamePattern.IsNull() && !plan.AsgNamePattern.IsUnknown() {
+		asgs, err := getSecurityGroupGUIDsByNamePattern(r.client, plan.AsgNamePattern.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to get security groups matching %s: %s", plan.AsgNamePattern.ValueString(), err),
+			)
+			return
+		}
+		asgsSet, aErr := types.SetValueFrom(ctx, types.StringType, asgs)
+		if aErr.HasError() {
+			resp.Diagnostics.Append(aErr...)
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("asgs"), asgsSet)...)
+	}
+
+	var planAsgs types.Set
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("asgs"), &planAsgs)...)
+	planAsgPaths := make(map[string][]path.Path)
+	addSetGUIDPaths(planAsgPaths, planAsgs, path.Root("asgs"))
+	resp.Diagnostics.Append(checkDuplicateGUIDs(r.options, "Security group", planAsgPaths)...)
+	resp.Diagnostics.Append(checkGloballyEnabledSecurityGroups(r.session.V3(), r.options, planAsgPaths, plan.Lifecycle.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.selector.selectorUnknown(planModel) {
+		return
+	}
+
+	selected, diags := r.selector.selectSpaces(ctx, planModel)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	spaces := spaceGUIDs(selected)
+	// nothing is bound once expired
+	if expired {
+		spaces = []string{}
+	}
+	spacesSet, aErr := types.SetValueFrom(ctx, types.StringType, spaces)
+	if aErr.HasError() {
+		resp.Diagnostics.Append(aErr...)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("spaces"), spacesSet)...)
+
+	// only spaces where bindings are added or removed need permissions, every space when security groups or lifecycle change
+	var stateSpaces []string
+	state.Spaces.ElementsAs(ctx, &stateSpaces, false)
+	allChanged := req.State.Raw.IsNull() || !planAsgs.Equal(state.Asgs) || !plan.Lifecycle.Equal(state.Lifecycle)
+
+	spacePaths := make(map[string][]path.Path)
+	for _, spaceID := range spaces {
+		if allChanged || !funk.ContainsString(stateSpaces, spaceID) {
+			spacePaths[spaceID] = []path.Path{path.Root("spaces").AtSetValue(types.StringValue(spaceID))}
+		}
+	}
+	for _, spaceID := range stateSpaces {
+		if !funk.ContainsString(spaces, spaceID) {
+			spacePaths[spaceID] = []path.Path{path.Root("spaces")}
+		}
+	}
+	resp.Diagnostics.Append(checkUserManagesSpaces(r.client, r.session.V3(), spacePaths)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	finalPlanModel := r.selector.newModel()
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, finalPlanModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	finalPlan := finalPlanModel.binding()
+	remove := disruptiveRemovedBinds(asgBindingBinds(ctx, state), asgBindingBinds(ctx, finalPlan), state.Lifecycle.ValueString(), finalPlan.Lifecycle.ValueString())
+	disrupted, diags := checkDisruption(r.session.V3(), remove, disruptionAllowed(finalPlan.AllowDisruption, expired))
+	resp.Diagnostics.Append(diags...)
+	setPlanDisruptedApps(ctx, req, resp, disrupted)
+}
+
+func (r *asgBindingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	configModel := r.selector.newModel()
+	configData := configModel.binding()
+
+	// Read Terraform configuration from the request into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, configModel)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if configData.Asgs.IsNull() && configData.AsgNamePattern.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("asgs"), "Attribute Error", "\"asgs\" or \"asg_name_pattern\" must be provided.")
+	}
+	if !configData.Asgs.IsNull() && !configData.AsgNamePattern.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("asgs"), "Attribute Error", "only one of \"asgs\" and \"asg_name_pattern\" can be provided.")
+	}
+	if !configData.AsgNamePattern.IsUnknown() && !configData.AsgNamePattern.IsNull() {
+		if _, err := pathpkg.Match(configData.AsgNamePattern.ValueString(), ""); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("asg_name_pattern"), "Attribute Error", fmt.Sprintf("invalid pattern %q: %s", configData.AsgNamePattern.ValueString(), err))
+		}
+	}
+
+	if !isValidLifecycle(configData.Lifecycle) {
+		resp.Diagnostics.AddAttributeError(path.Root("lifecycle"), "Attribute Error", "\"lifecycle\" must be either \"running\" or \"staging\".")
+	}
+
+	resp.Diagnostics.Append(r.selector.validateSelector(ctx, configModel)...)
+}
+
+// ImportState import by identity only, selected spaces where security groups are bound are taken on the following read
+func (r *asgBindingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != "" {
+		resp.Diagnostics.AddError(
+			"Unsupported Import",
+			fmt.Sprintf("cfsecurity_%s can only be imported by identity, use an import block with an identity", r.selector.typeName()),
+		)
+		return
+	}
+
+	dataModel, identity, diags := r.selector.importModel(ctx, req.Identity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(checkIdentityGUIDs("asg_guids", identity.AsgGUIDs...)...)
+	if !isValidLifecycle(identity.Lifecycle) {
+		resp.Diagnostics.AddAttributeError(path.Root("lifecycle"), "Invalid Identity", "\"lifecycle\" must be either \"running\" or \"staging\".")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to generate uuid: %s", err),
+		)
+		return
+	}
+	asgs, diags := types.SetValueFrom(ctx, types.StringType, identity.AsgGUIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	lifecycle := identity.Lifecycle
+	if lifecycle.ValueString() == "" {
+		lifecycle = types.StringNull()
+	}
+
+	*dataModel.binding() = asgBindingResourceModel{
+		Id:              types.StringValue(id),
+		Asgs:            asgs,
+		AsgNamePattern:  types.StringNull(),
+		Lifecycle:       lifecycle,
+		Spaces:          types.SetValueMust(types.StringType, []attr.Value{}),
+		AllowDisruption: types.BoolNull(),
+		DisruptedApps:   types.SetValueMust(disruptedAppType, []attr.Value{}),
+		ExpiresAt:       types.StringNull(),
+		Expired:         types.BoolValue(false),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, dataModel)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedKey, []byte("true"))...)
+	// identity is normalized to the one kept on read
+	resp.Diagnostics.Append(r.setIdentity(ctx, resp.Identity, dataModel)...)
+}
+
+// resolvePlan resolve security groups and spaces left unknown by the plan, spaces are emptied once bindings expired
+func (r *asgBindingResource) resolvePlan(ctx context.Context, planModel asgBindingModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	plan := planModel.binding()
+
+	if plan.Asgs.IsUnknown() {
+		asgs, err := getSecurityGroupGUIDsByNamePattern(r.client, plan.AsgNamePattern.ValueString())
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to get security groups matching %s: %s", plan.AsgNamePattern.ValueString(), err),
+			)
+			return diags
+		}
+		plan.Asgs, _ = types.SetValueFrom(ctx, types.StringType, asgs)
+	}
+
+	plan.Expired = appliedExpired(plan.Expired, plan.ExpiresAt)
+	if plan.Spaces.IsUnknown() {
+		selected, sDiags := r.selector.selectSpaces(ctx, planModel)
+		diags.Append(sDiags...)
+		if diags.HasError() {
+			return diags
+		}
+		spaces := spaceGUIDs(selected)
+		// nothing is bound once expired
+		if plan.Expired.ValueBool() {
+			spaces = []string{}
+		}
+		plan.Spaces, _ = types.SetValueFrom(ctx, types.StringType, spaces)
+	}
+	return diags
+}
+
+// identity return identity of the resource, security groups are sorted to not depend on their order
+func (r *asgBindingResource) identity(ctx context.Context, dataModel asgBindingModel) interface{} {
+	data := dataModel.binding()
+	asgs := make([]string, 0)
+	data.Asgs.ElementsAs(ctx, &asgs, false)
+	sort.Strings(asgs)
+	return r.selector.newIdentity(dataModel, asgBindingResourceIdentityModel{
+		AsgGUIDs:  asgs,
+		Lifecycle: data.Lifecycle,
+	})
+}
+
+// setIdentity set identity of the resource from its state, nothing is done when terraform does not support identity
+func (r *asgBindingResource) setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, dataModel asgBindingModel) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, r.identity(ctx, dataModel))
+}
+
+// asgBindingBinds return every security group and space pair managed by the resource
+func asgBindingBinds(ctx context.Context, data *asgBindingResourceModel) []bind {
+	var asgs, spaces []string
+	data.Asgs.ElementsAs(ctx, &asgs, false)
+	data.Spaces.ElementsAs(ctx, &spaces, false)
+
+	return asgSpacesBinds(asgs, spaces)
+}
+
+// spaceGUIDs return guids of spaces
+func spaceGUIDs(spaces []client.Space) []string {
+	guids := make([]string, 0, len(spaces))
+	for _, space := range spaces {
+		guids = append(guids, space.GUID)
+	}
+	return guids
+}
@@ -0,0 +1,637 @@
+package cfsecurity
+
+import (
+	"context"
+	"fmt"
+	pathpkg "path"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3/constant"
+	"code.cloudfoundry.org/cli/v8/resources"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+	"github.com/thoas/go-funk"
+)
+
+// bindSecurityGroupForLifecycle bind a security group to a space for the given lifecycle,
+// both running and staging are bound when lifecycle is empty
+func bindSecurityGroupForLifecycle(clt *client.Client, asgID, spaceID, lifecycle string) error {
+	switch lifecycle {
+	case lifecycleRunning:
+		return clt.BindRunningSecGroupToSpace(asgID, spaceID, clt.GetEndpoint())
+	case lifecycleStaging:
+		return clt.BindStagingSecGroupToSpace(asgID, spaceID, clt.GetEndpoint())
+	}
+	return clt.BindSecurityGroup(asgID, spaceID, clt.GetEndpoint())
+}
+
+// unbindSecurityGroupForLifecycle unbind a security group from a space for the given lifecycle,
+// running and staging are unbound one after the other when lifecycle is empty, a binding already removed or a deleted
+// security group or space is not an error
+func unbindSecurityGroupForLifecycle(clt *client.Client, ccv3Client *ccv3.Client, asgID, spaceID, lifecycle string) error {
+	lifecycles := []string{lifecycle}
+	if lifecycle == "" {
+		lifecycles = []string{lifecycleRunning, lifecycleStaging}
+	}
+	for _, unbindLifecycle := range lifecycles {
+		var err error
+		if unbindLifecycle == lifecycleRunning {
+			err = clt.UnBindRunningSecGroupToSpace(asgID, spaceID, clt.GetEndpoint())
+		} else {
+			err = clt.UnBindStagingSecGroupToSpace(asgID, spaceID, clt.GetEndpoint())
+		}
+		if err == nil || isNotFoundErr(err) {
+			continue
+		}
+		// a security group or a space deleted in the meantime is already unbound
+		vanishedAsgIDs, vanishedSpaceIDs, vErr := getVanishedBindTargets(clt, ccv3Client, []bind{{
+			AsgID:   types.StringValue(asgID),
+			SpaceID: types.StringValue(spaceID),
+		}}, nil)
+		if vErr == nil && (len(vanishedAsgIDs) > 0 || len(vanishedSpaceIDs) > 0) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// isSecurityGroupBound return true if security group is bound to the space for the given lifecycle,
+// it must be bound for both running and staging when lifecycle is empty
+func isSecurityGroupBound(secGroup client.SecurityGroup, spaceID, lifecycle string) bool {
+	matchSpace := func(object interface{}) bool {
+		return object.(client.Data).GUID == spaceID
+	}
+	running := isInSlice(secGroup.Relationships.Running_Spaces.Data, matchSpace)
+	staging := isInSlice(secGroup.Relationships.Staging_Spaces.Data, matchSpace)
+	switch lifecycle {
+	case lifecycleRunning:
+		return running
+	case lifecycleStaging:
+		return staging
+	}
+	return running && staging
+}
+
+// matchNamePatterns return true if name match one of include patterns (or if there is none)
+// and does not match any of exclude patterns, patterns use shell glob syntax (e.g.: prod-*)
+func matchNamePatterns(name string, include []string, exclude []string) bool {
+	for _, pattern := range exclude {
+		if ok, _ := pathpkg.Match(pattern, name); ok {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, pattern := range include {
+		if ok, _ := pathpkg.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// getBoundSpaces return guids of spaces where every given security group is bound for the given lifecycle
+func getBoundSpaces(clt *client.Client, asgIDs []string, spaceIDs []string, lifecycle string) ([]string, error) {
+	secGroups, err := clt.GetSecGroups([]ccv3.Query{}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	boundSpaces := make([]string, 0)
+	for _, spaceID := range funk.UniqString(spaceIDs) {
+		bound := true
+		for _, asgID := range asgIDs {
+			if !isInSlice(secGroups.Resources, func(object interface{}) bool {
+				secGroup := object.(client.SecurityGroup)
+				return secGroup.GUID == asgID && isSecurityGroupBound(secGroup, spaceID, lifecycle)
+			}) {
+				bound = false
+				break
+			}
+		}
+		if bound {
+			boundSpaces = append(boundSpaces, spaceID)
+		}
+	}
+	return boundSpaces, nil
+}
+
+// asgSpacesBinds return every security group and space pair
+func asgSpacesBinds(asgIDs []string, spaceIDs []string) []bind {
+	binds := make([]bind, 0, len(asgIDs)*len(spaceIDs))
+	for _, asgID := range asgIDs {
+		for _, spaceID := range spaceIDs {
+			binds = append(binds, bind{
+				AsgID:   types.StringValue(asgID),
+				SpaceID: types.StringValue(spaceID),
+			})
+		}
+	}
+	return binds
+}
+
+// getSecurityGroupGUIDsByNamePattern return guids of security groups with a name matching the pattern,
+// pattern use shell glob syntax (e.g.: platform-egress-*)
+func getSecurityGroupGUIDsByNamePattern(clt *client.Client, pattern string) ([]string, error) {
+	secGroups, err := clt.GetSecGroups([]ccv3.Query{}, 0)
+	if err != nil {
+		return nil, err
+	}
+	guids := make([]string, 0)
+	for _, secGroup := range secGroups.Resources {
+		if ok, _ := pathpkg.Match(pattern, secGroup.Name); ok {
+			guids = append(guids, secGroup.GUID)
+		}
+	}
+	return guids, nil
+}
+
+// addBindingDiagnostic add a warning on path, or an error when strict mode is enabled
+func addBindingDiagnostic(diags *diag.Diagnostics, options providerOptions, p path.Path, summary string, detail string) {
+	if options.StrictMode {
+		diags.AddAttributeError(p, summary, detail)
+		return
+	}
+	diags.AddAttributeWarning(p, summary, detail)
+}
+
+// checkDuplicateGUIDs report guids given more than once with a different casing, paths are indexed by guid
+func checkDuplicateGUIDs(options providerOptions, kind string, guidPaths map[string][]path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	byLower := make(map[string][]string)
+	for guid := range guidPaths {
+		byLower[strings.ToLower(guid)] = append(byLower[strings.ToLower(guid)], guid)
+	}
+	for _, guids := range byLower {
+		if len(guids) < 2 {
+			continue
+		}
+		sort.Strings(guids)
+		for _, guid := range guids {
+			for _, p := range guidPaths[guid] {
+				addBindingDiagnostic(&diags, options, p, "Duplicated Binding",
+					fmt.Sprintf("%s %s is given more than once with a different casing (%s)", kind, guid, strings.Join(guids, ", ")))
+			}
+		}
+	}
+	return diags
+}
+
+// checkGloballyEnabledSecurityGroups report security groups already globally enabled for the bound lifecycle,
+// binding them to spaces has no effect, paths are indexed by security group guid
+func checkGloballyEnabledSecurityGroups(ccv3Client *ccv3.Client, options providerOptions, guidPaths map[string][]path.Path, lifecycle string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(guidPaths) == 0 {
+		return diags
+	}
+
+	// globally enabled flags are only given by the cloud controller
+	secGroups, _, err := ccv3Client.GetSecurityGroups()
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get security groups : %s", err),
+		)
+		return diags
+	}
+	return globallyEnabledDiagnostics(secGroups, options, guidPaths, lifecycle)
+}
+
+// globallyEnabledDiagnostics return diagnostics on paths of security groups globally enabled for the bound lifecycle,
+// for both running and staging when lifecycle is empty
+func globallyEnabledDiagnostics(secGroups []resources.SecurityGroup, options providerOptions, guidPaths map[string][]path.Path, lifecycle string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, secGroup := range secGroups {
+		enabledFor := make([]string, 0)
+		if lifecycle != lifecycleStaging && secGroup.RunningGloballyEnabled != nil && *secGroup.RunningGloballyEnabled {
+			enabledFor = append(enabledFor, lifecycleRunning)
+		}
+		if lifecycle != lifecycleRunning && secGroup.StagingGloballyEnabled != nil && *secGroup.StagingGloballyEnabled {
+			enabledFor = append(enabledFor, lifecycleStaging)
+		}
+		if len(enabledFor) == 0 {
+			continue
+		}
+		for guid, paths := range guidPaths {
+			if !strings.EqualFold(guid, secGroup.GUID) {
+				continue
+			}
+			for _, p := range paths {
+				addBindingDiagnostic(&diags, options, p, "Redundant Binding",
+					fmt.Sprintf("Security group %s (%s) is globally enabled for %s, binding it to spaces has no effect for this lifecycle",
+						secGroup.Name, secGroup.GUID, strings.Join(enabledFor, " and ")))
+			}
+		}
+	}
+	return diags
+}
+
+// getVanishedBindTargets return guids of security groups and spaces of binds which do not exist anymore, those missing
+// from security groups and spaces visible to the user are only vanished when the cloud controller answers not found,
+// security groups are fetched when not given
+func getVanishedBindTargets(clt *client.Client, ccv3Client *ccv3.Client, binds []bind, secGroups *client.SecurityGroups) (asgIDs []string, spaceIDs []string, err error) {
+	if secGroups == nil {
+		allSecGroups, err := clt.GetSecGroups([]ccv3.Query{}, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		secGroups = &allSecGroups
+	}
+
+	missingAsgIDs := make([]string, 0)
+	missingSpaceIDs := make([]string, 0)
+	for _, aBind := range binds {
+		missingSpaceIDs = append(missingSpaceIDs, aBind.SpaceID.ValueString())
+		asgID := aBind.AsgID.ValueString()
+		if funk.ContainsString(missingAsgIDs, asgID) || isInSlice(secGroups.Resources, func(object interface{}) bool {
+			return object.(client.SecurityGroup).GUID == asgID
+		}) {
+			continue
+		}
+		missingAsgIDs = append(missingAsgIDs, asgID)
+	}
+
+	missingSpaceIDs = funk.UniqString(missingSpaceIDs)
+	for _, chunk := range chunkStrings(append([]string{}, missingSpaceIDs...), 50) {
+		spaces, err := clt.GetSpacesWithOrg([]ccv3.Query{{Key: ccv3.GUIDFilter, Values: chunk}}, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, space := range spaces.Resources {
+			missingSpaceIDs = funk.SubtractString(missingSpaceIDs, []string{space.GUID})
+		}
+	}
+
+	asgIDs = make([]string, 0)
+	for _, asgID := range missingAsgIDs {
+		found, err := ccResourceExists(ccv3Client, clt.GetApiUrl()+"/v3/security_groups/"+asgID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !found {
+			asgIDs = append(asgIDs, asgID)
+		}
+	}
+	spaceIDs = make([]string, 0)
+	for _, spaceID := range missingSpaceIDs {
+		found, err := ccResourceExists(ccv3Client, clt.GetApiUrl()+"/v3/spaces/"+spaceID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !found {
+			spaceIDs = append(spaceIDs, spaceID)
+		}
+	}
+	return asgIDs, spaceIDs, nil
+}
+
+type disruptedApp struct {
+	AppID   types.String `tfsdk:"app_id"`
+	AppName types.String `tfsdk:"app_name"`
+	SpaceID types.String `tfsdk:"space_id"`
+	AsgID   types.String `tfsdk:"asg_id"`
+}
+
+var disruptedAppType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"app_id":   types.StringType,
+		"app_name": types.StringType,
+		"space_id": types.StringType,
+		"asg_id":   types.StringType,
+	},
+}
+
+// disruptedAppsAttribute return schema of the computed attribute listing apps disrupted by unbinds
+func disruptedAppsAttribute() schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		Description: "Started apps of spaces where security groups are unbound by the plan, their egress traffic may be cut",
+		Computed:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"app_id": schema.StringAttribute{
+					Description: "The app guid",
+					Computed:    true,
+				},
+				"app_name": schema.StringAttribute{
+					Description: "The app name",
+					Computed:    true,
+				},
+				"space_id": schema.StringAttribute{
+					Description: "The space guid of the app",
+					Computed:    true,
+				},
+				"asg_id": schema.StringAttribute{
+					Description: "The security group guid unbound from the space",
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+// allowDisruptionAttribute return schema of the setting allowing to unbind security groups from spaces with started apps
+func allowDisruptionAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Allow to unbind security groups from spaces with started apps (default: false)",
+		Optional:    true,
+	}
+}
+
+// getDisruptedApps return started apps of spaces where security groups of removed binds are unbound
+func getDisruptedApps(ccv3Client *ccv3.Client, removed []bind) ([]disruptedApp, error) {
+	disrupted := make([]disruptedApp, 0)
+	spaceIDs := make([]string, 0, len(removed))
+	for _, rBind := range removed {
+		spaceIDs = append(spaceIDs, rBind.SpaceID.ValueString())
+	}
+
+	for _, chunk := range chunkStrings(funk.UniqString(spaceIDs), 50) {
+		apps, _, err := ccv3Client.GetApplications(ccv3.Query{Key: ccv3.SpaceGUIDFilter, Values: chunk})
+		if err != nil {
+			return nil, err
+		}
+		for _, app := range apps {
+			if app.State != constant.ApplicationStarted {
+				continue
+			}
+			for _, rBind := range removed {
+				if rBind.SpaceID.ValueString() != app.SpaceGUID {
+					continue
+				}
+				disrupted = append(disrupted, disruptedApp{
+					AppID:   types.StringValue(app.GUID),
+					AppName: types.StringValue(app.Name),
+					SpaceID: types.StringValue(app.SpaceGUID),
+					AsgID:   rBind.AsgID,
+				})
+			}
+		}
+	}
+	return disrupted, nil
+}
+
+// addDisruptionDiagnostics add a warning listing disrupted apps, or an error when disruption is not allowed
+func addDisruptionDiagnostics(diags *diag.Diagnostics, disrupted []disruptedApp, allowDisruption bool) {
+	if len(disrupted) == 0 {
+		return
+	}
+	details := make([]string, 0, len(disrupted))
+	for _, app := range disrupted {
+		details = append(details, fmt.Sprintf("  - app %s (%s) in space %s loses security group %s",
+			app.AppName.ValueString(), app.AppID.ValueString(), app.SpaceID.ValueString(), app.AsgID.ValueString()))
+	}
+	sort.Strings(details)
+	if !allowDisruption {
+		diags.AddError(
+			"Disruptive Unbind",
+			fmt.Sprintf("Unbinding security groups may cut egress traffic of started apps, set allow_disruption to true to proceed:\n%s", strings.Join(details, "\n")),
+		)
+		return
+	}
+	diags.AddWarning(
+		"Disruptive Unbind",
+		fmt.Sprintf("Unbinding security groups may cut egress traffic of started apps:\n%s", strings.Join(details, "\n")),
+	)
+}
+
+// checkDisruption return diagnostics about started apps disrupted by removed binds
+func checkDisruption(ccv3Client *ccv3.Client, removed []bind, allowDisruption bool) ([]disruptedApp, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	disrupted, err := getDisruptedApps(ccv3Client, removed)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get apps of spaces : %s", err),
+		)
+		return nil, diags
+	}
+	addDisruptionDiagnostics(&diags, disrupted, allowDisruption)
+	return disrupted, diags
+}
+
+// disruptionAllowed return whether started apps may be disrupted by unbinding security groups, bindings removed because
+// they expired are always unbound and disrupted apps only reported with a warning, otherwise expires_at would not be honored
+func disruptionAllowed(allowDisruption types.Bool, expired bool) bool {
+	return allowDisruption.ValueBool() || expired
+}
+
+// setPlanDisruptedApps set disrupted_apps in plan, it is kept as in state when nothing else changes to not show a diff
+func setPlanDisruptedApps(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, disrupted []disruptedApp) {
+	var current types.Set
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("disrupted_apps"), &current)...)
+	if !current.IsUnknown() && len(disrupted) == 0 && resp.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+	disruptedSet, aErr := types.SetValueFrom(ctx, disruptedAppType, disrupted)
+	resp.Diagnostics.Append(aErr...)
+	if aErr.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("disrupted_apps"), disruptedSet)...)
+}
+
+// appliedDisruptedApps return disrupted apps to save in state after apply, the planned value when it is known
+func appliedDisruptedApps(ctx context.Context, planned types.Set, disrupted []disruptedApp) (types.Set, diag.Diagnostics) {
+	if !planned.IsUnknown() {
+		return planned, nil
+	}
+	return types.SetValueFrom(ctx, disruptedAppType, disrupted)
+}
+
+// disruptiveRemovedBinds return binds which lose a lifecycle when going from old to new bindings, every old binding
+// loses one when lifecycle is narrowed from both to running or staging, or switched from one to the other
+func disruptiveRemovedBinds(oldBinds []bind, newBinds []bind, oldLifecycle string, newLifecycle string) []bind {
+	if oldLifecycle != newLifecycle && newLifecycle != "" {
+		return oldBinds
+	}
+	remove, _ := getListBindChanges(oldBinds, newBinds)
+	return remove
+}
+
+// expiresAtAttribute return schema of the time after which bindings of a resource are removed
+func expiresAtAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "RFC3339 timestamp after which bindings are removed, refresh reports them as expired and next plan proposes to unbind them",
+		Optional:    true,
+		Validators: []validator.String{
+			rfc3339Validator{},
+		},
+	}
+}
+
+// expiredAttribute return schema of the computed attribute telling that bindings have been removed because expires_at has passed
+func expiredAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "True when expires_at has passed, bindings are then removed",
+		Computed:    true,
+	}
+}
+
+// isExpired return true when expiresAt is set and has passed
+func isExpired(expiresAt types.String) bool {
+	if expiresAt.IsNull() || expiresAt.IsUnknown() {
+		return false
+	}
+	expiry, err := time.Parse(time.RFC3339, expiresAt.ValueString())
+	return err == nil && !time.Now().Before(expiry)
+}
+
+// addExpiredWarning warn that bindings of a resource have expired and how long ago
+func addExpiredWarning(diags *diag.Diagnostics, id string, expiresAt types.String) {
+	expiry, _ := time.Parse(time.RFC3339, expiresAt.ValueString())
+	diags.AddWarning(
+		"Bindings Expired",
+		fmt.Sprintf("Bindings of %s expired %s ago (expires_at %s), they are removed on next apply unless expires_at is extended",
+			id, time.Since(expiry).Round(time.Second), expiresAt.ValueString()),
+	)
+}
+
+// checkExpiresAt check a new or changed expires_at: it must not be more than max_duration of the provider from now,
+// and not be already passed on creation
+func checkExpiresAt(options providerOptions, planned types.String, prior types.String, creating bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if planned.IsNull() || planned.IsUnknown() || (!creating && planned.Equal(prior)) {
+		return diags
+	}
+	expiry, err := time.Parse(time.RFC3339, planned.ValueString())
+	if err != nil {
+		return diags
+	}
+	if creating && !time.Now().Before(expiry) {
+		diags.AddAttributeError(
+			path.Root("expires_at"),
+			"Bindings Already Expired",
+			fmt.Sprintf("expires_at %s has already passed, nothing would be bound", planned.ValueString()),
+		)
+		return diags
+	}
+	if options.MaxDuration > 0 && time.Until(expiry) > options.MaxDuration {
+		diags.AddAttributeError(
+			path.Root("expires_at"),
+			"Expiry Too Far",
+			fmt.Sprintf("expires_at %s is more than max_duration %s from now, set in provider configuration", planned.ValueString(), options.MaxDuration),
+		)
+	}
+	return diags
+}
+
+// setPlanExpired set expired in plan from expires_at, it shows as a change when expires_at has passed since last apply
+func setPlanExpired(ctx context.Context, resp *resource.ModifyPlanResponse, expiresAt types.String) bool {
+	expired := isExpired(expiresAt)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expired"), types.BoolValue(expired))...)
+	return expired
+}
+
+// appliedExpired return expired to save in state after apply, the planned value when it is known
+func appliedExpired(planned types.Bool, expiresAt types.String) types.Bool {
+	if !planned.IsUnknown() {
+		return planned
+	}
+	return types.BoolValue(isExpired(expiresAt))
+}
+
+// activeBinds return binds which must be in place, none once expired
+func activeBinds(binds []bind, expired types.Bool) []bind {
+	if expired.ValueBool() {
+		return []bind{}
+	}
+	return binds
+}
+
+// listedBinding is a security group bound to a space found when listing bindings
+type listedBinding struct {
+	AsgID     string
+	AsgName   string
+	SpaceID   string
+	SpaceName string
+	// Lifecycle is running or staging when the security group is bound for this lifecycle only, empty when bound for both
+	Lifecycle string
+}
+
+// getListedBindings return security groups bound to spaces for running or staging lifecycle, once per space with the lifecycle
+// it is bound for, restricted to spaces of orgID, to spaceID and to security groups with a name matching asgNamePattern
+// when they are not empty
+func getListedBindings(clt *client.Client, secGroups client.SecurityGroups, orgID, spaceID, asgNamePattern string) ([]listedBinding, error) {
+	var spaceFilter []string
+	if orgID != "" {
+		spaces, err := clt.GetSpacesWithOrg([]ccv3.Query{{Key: ccv3.OrganizationGUIDFilter, Values: []string{orgID}}}, 0)
+		if err != nil {
+			return nil, err
+		}
+		spaceFilter = make([]string, 0, len(spaces.Resources))
+		for _, space := range spaces.Resources {
+			spaceFilter = append(spaceFilter, space.GUID)
+		}
+	}
+	if spaceID != "" {
+		if spaceFilter != nil && !funk.ContainsString(spaceFilter, spaceID) {
+			return []listedBinding{}, nil
+		}
+		spaceFilter = []string{spaceID}
+	}
+	var include []string
+	if asgNamePattern != "" {
+		include = []string{asgNamePattern}
+	}
+
+	listed := make([]listedBinding, 0)
+	for _, secGroup := range secGroups.Resources {
+		if !matchNamePatterns(secGroup.Name, include, nil) {
+			continue
+		}
+		spaceNames := make(map[string]string)
+		spaceIDs := make([]string, 0)
+		spaceLifecycles := make(map[string][]string)
+		for _, lifecycle := range []string{lifecycleRunning, lifecycleStaging} {
+			spaces := secGroup.Relationships.Running_Spaces.Data
+			if lifecycle == lifecycleStaging {
+				spaces = secGroup.Relationships.Staging_Spaces.Data
+			}
+			for _, space := range spaces {
+				if spaceFilter != nil && !funk.ContainsString(spaceFilter, space.GUID) {
+					continue
+				}
+				if _, ok := spaceNames[space.GUID]; !ok {
+					spaceIDs = append(spaceIDs, space.GUID)
+				}
+				if space.SpaceName != "" || spaceNames[space.GUID] == "" {
+					spaceNames[space.GUID] = space.SpaceName
+				}
+				if !funk.ContainsString(spaceLifecycles[space.GUID], lifecycle) {
+					spaceLifecycles[space.GUID] = append(spaceLifecycles[space.GUID], lifecycle)
+				}
+			}
+		}
+		for _, boundSpaceID := range spaceIDs {
+			lifecycle := ""
+			if len(spaceLifecycles[boundSpaceID]) == 1 {
+				lifecycle = spaceLifecycles[boundSpaceID][0]
+			}
+			listed = append(listed, listedBinding{
+				AsgID:     secGroup.GUID,
+				AsgName:   secGroup.Name,
+				SpaceID:   boundSpaceID,
+				SpaceName: spaceNames[boundSpaceID],
+				Lifecycle: lifecycle,
+			})
+		}
+	}
+	sort.Slice(listed, func(i, j int) bool {
+		if listed[i].AsgName != listed[j].AsgName {
+			return listed[i].AsgName < listed[j].AsgName
+		}
+		return listed[i].SpaceID < listed[j].SpaceID
+	})
+	return listed, nil
+}
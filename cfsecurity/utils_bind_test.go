@@ -0,0 +1,328 @@
+package cfsecurity
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/v8/resources"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+)
+
+func TestMatchNamePatterns(t *testing.T) {
+	tests := []struct {
+		name    string
+		space   string
+		include []string
+		exclude []string
+		want    bool
+	}{
+		{name: "no pattern", space: "prod-eu", want: true},
+		{name: "included", space: "prod-eu", include: []string{"prod-*"}, want: true},
+		{name: "not included", space: "dev-eu", include: []string{"prod-*"}, want: false},
+		{name: "one of includes", space: "dev-eu", include: []string{"prod-*", "dev-*"}, want: true},
+		{name: "excluded", space: "sandbox-eu", exclude: []string{"sandbox-*"}, want: false},
+		{name: "not excluded", space: "prod-eu", exclude: []string{"sandbox-*"}, want: true},
+		{name: "exclude wins over include", space: "prod-sandbox", include: []string{"prod-*"}, exclude: []string{"*-sandbox"}, want: false},
+		{name: "exact name", space: "prod", include: []string{"prod"}, want: true},
+		{name: "pattern matches whole name", space: "prod-eu", include: []string{"prod"}, want: false},
+		{name: "single character wildcard", space: "prod-1", include: []string{"prod-?"}, want: true},
+		{name: "character class", space: "prod-b", include: []string{"prod-[a-c]"}, want: true},
+		{name: "invalid include never matches", space: "prod-eu", include: []string{"prod-[*"}, want: false},
+		{name: "invalid exclude never excludes", space: "prod-eu", exclude: []string{"prod-[*"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchNamePatterns(tt.space, tt.include, tt.exclude); got != tt.want {
+				t.Errorf("matchNamePatterns(%q, %v, %v) = %v, want %v", tt.space, tt.include, tt.exclude, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGloballyEnabledDiagnostics(t *testing.T) {
+	enabled, disabled := true, false
+	secGroups := []resources.SecurityGroup{
+		{GUID: "running-asg", Name: "running", RunningGloballyEnabled: &enabled, StagingGloballyEnabled: &disabled},
+		{GUID: "staging-asg", Name: "staging", StagingGloballyEnabled: &enabled},
+		{GUID: "both-asg", Name: "both", RunningGloballyEnabled: &enabled, StagingGloballyEnabled: &enabled},
+		{GUID: "space-asg", Name: "space", RunningGloballyEnabled: &disabled, StagingGloballyEnabled: &disabled},
+	}
+	// flags are decoded from security groups as given by the cloud controller
+	var decoded resources.SecurityGroup
+	if err := json.Unmarshal([]byte(`{"guid":"decoded-asg","name":"decoded","globally_enabled":{"running":true,"staging":false}}`), &decoded); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	secGroups = append(secGroups, decoded)
+
+	tests := []struct {
+		name      string
+		guid      string
+		lifecycle string
+		strict    bool
+		want      diag.Severity
+	}{
+		{name: "running enabled bound for both", guid: "running-asg", want: diag.SeverityWarning},
+		{name: "running enabled bound for running", guid: "running-asg", lifecycle: lifecycleRunning, want: diag.SeverityWarning},
+		{name: "running enabled bound for staging", guid: "running-asg", lifecycle: lifecycleStaging},
+		{name: "staging enabled bound for staging", guid: "staging-asg", lifecycle: lifecycleStaging, want: diag.SeverityWarning},
+		{name: "staging enabled bound for running", guid: "staging-asg", lifecycle: lifecycleRunning},
+		{name: "both enabled", guid: "both-asg", lifecycle: lifecycleRunning, want: diag.SeverityWarning},
+		{name: "guid with another casing", guid: "BOTH-ASG", want: diag.SeverityWarning},
+		{name: "strict mode", guid: "both-asg", strict: true, want: diag.SeverityError},
+		{name: "decoded running enabled", guid: "decoded-asg", want: diag.SeverityWarning},
+		{name: "decoded staging disabled", guid: "decoded-asg", lifecycle: lifecycleStaging},
+		{name: "not globally enabled", guid: "space-asg"},
+		{name: "unknown security group", guid: "other-asg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guidPaths := map[string][]path.Path{tt.guid: {path.Root("asgs")}}
+			diags := globallyEnabledDiagnostics(secGroups, providerOptions{StrictMode: tt.strict}, guidPaths, tt.lifecycle)
+			if tt.want == diag.SeverityInvalid {
+				if len(diags) != 0 {
+					t.Fatalf("expected no diagnostic, got %v", diags)
+				}
+				return
+			}
+			if len(diags) != 1 {
+				t.Fatalf("expected one diagnostic, got %v", diags)
+			}
+			if diags[0].Severity() != tt.want || diags[0].Summary() != "Redundant Binding" {
+				t.Errorf("got %s %q, want %s \"Redundant Binding\"", diags[0].Severity(), diags[0].Summary(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDisruptiveRemovedBinds(t *testing.T) {
+	binds := func(keys ...string) []bind {
+		result := make([]bind, 0, len(keys))
+		for _, key := range keys {
+			parts := strings.Split(key, "/")
+			result = append(result, bind{AsgID: types.StringValue(parts[0]), SpaceID: types.StringValue(parts[1])})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name         string
+		oldBinds     []bind
+		newBinds     []bind
+		oldLifecycle string
+		newLifecycle string
+		want         []string
+	}{
+		{name: "nothing changes", oldBinds: binds("asg-1/space-1"), newBinds: binds("asg-1/space-1"), want: []string{}},
+		{name: "binding added", oldBinds: binds("asg-1/space-1"), newBinds: binds("asg-1/space-1", "asg-1/space-2"), want: []string{}},
+		{name: "binding removed", oldBinds: binds("asg-1/space-1", "asg-1/space-2"), newBinds: binds("asg-1/space-1"), want: []string{"asg-1/space-2"}},
+		{name: "everything removed", oldBinds: binds("asg-1/space-1", "asg-2/space-1"), newBinds: nil, want: []string{"asg-1/space-1", "asg-2/space-1"}},
+		{name: "lifecycle narrowed to running", oldBinds: binds("asg-1/space-1"), newBinds: binds("asg-1/space-1"), newLifecycle: lifecycleRunning, want: []string{"asg-1/space-1"}},
+		{name: "lifecycle narrowed to staging", oldBinds: binds("asg-1/space-1"), newBinds: binds("asg-1/space-1"), newLifecycle: lifecycleStaging, want: []string{"asg-1/space-1"}},
+		{name: "lifecycle switched", oldBinds: binds("asg-1/space-1"), newBinds: binds("asg-1/space-1"), oldLifecycle: lifecycleStaging, newLifecycle: lifecycleRunning, want: []string{"asg-1/space-1"}},
+		{name: "lifecycle widened to both", oldBinds: binds("asg-1/space-1", "asg-1/space-2"), newBinds: binds("asg-1/space-1"), oldLifecycle: lifecycleRunning, want: []string{"asg-1/space-2"}},
+		{name: "same lifecycle", oldBinds: binds("asg-1/space-1"), newBinds: binds("asg-1/space-1"), oldLifecycle: lifecycleRunning, newLifecycle: lifecycleRunning, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, aBind := range disruptiveRemovedBinds(tt.oldBinds, tt.newBinds, tt.oldLifecycle, tt.newLifecycle) {
+				got = append(got, bindKey(aBind))
+			}
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got removed binds %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsExpired(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresAt types.String
+		want      bool
+	}{
+		{name: "not set", expiresAt: types.StringNull(), want: false},
+		{name: "unknown", expiresAt: types.StringUnknown(), want: false},
+		{name: "passed", expiresAt: types.StringValue(time.Now().Add(-time.Minute).Format(time.RFC3339)), want: true},
+		{name: "to come", expiresAt: types.StringValue(time.Now().Add(time.Hour).Format(time.RFC3339)), want: false},
+		{name: "invalid", expiresAt: types.StringValue("tomorrow"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isExpired(tt.expiresAt); got != tt.want {
+				t.Errorf("isExpired(%s) = %v, want %v", tt.expiresAt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckExpiresAt(t *testing.T) {
+	at := func(d time.Duration) types.String {
+		return types.StringValue(time.Now().Add(d).Format(time.RFC3339))
+	}
+	past, soon, later := at(-time.Hour), at(time.Hour), at(48*time.Hour)
+
+	tests := []struct {
+		name        string
+		maxDuration time.Duration
+		planned     types.String
+		prior       types.String
+		creating    bool
+		want        string
+	}{
+		{name: "not set", planned: types.StringNull(), prior: types.StringNull(), creating: true},
+		{name: "unknown", planned: types.StringUnknown(), prior: types.StringNull(), creating: true},
+		{name: "to come on creation", planned: soon, prior: types.StringNull(), creating: true},
+		{name: "passed on creation", planned: past, prior: types.StringNull(), creating: true, want: "Bindings Already Expired"},
+		{name: "passed on update", planned: past, prior: soon},
+		{name: "unchanged and passed", planned: past, prior: past},
+		{name: "within max duration", maxDuration: 24 * time.Hour, planned: soon, prior: types.StringNull(), creating: true},
+		{name: "beyond max duration", maxDuration: 24 * time.Hour, planned: later, prior: types.StringNull(), creating: true, want: "Expiry Too Far"},
+		{name: "extended beyond max duration", maxDuration: 24 * time.Hour, planned: later, prior: soon, want: "Expiry Too Far"},
+		{name: "unchanged beyond max duration", maxDuration: 24 * time.Hour, planned: later, prior: later},
+		{name: "no max duration", planned: later, prior: types.StringNull(), creating: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := checkExpiresAt(providerOptions{MaxDuration: tt.maxDuration}, tt.planned, tt.prior, tt.creating)
+			if tt.want == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				return
+			}
+			if len(diags) != 1 || diags[0].Summary() != tt.want {
+				t.Errorf("got %v, want error %q", diags, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppliedExpired(t *testing.T) {
+	past := types.StringValue(time.Now().Add(-time.Minute).Format(time.RFC3339))
+	tests := []struct {
+		name      string
+		planned   types.Bool
+		expiresAt types.String
+		want      bool
+	}{
+		{name: "planned not expired", planned: types.BoolValue(false), expiresAt: past, want: false},
+		{name: "planned expired", planned: types.BoolValue(true), expiresAt: types.StringNull(), want: true},
+		{name: "unknown and passed", planned: types.BoolUnknown(), expiresAt: past, want: true},
+		{name: "unknown and not set", planned: types.BoolUnknown(), expiresAt: types.StringNull(), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := appliedExpired(tt.planned, tt.expiresAt)
+			if got.IsUnknown() || got.ValueBool() != tt.want {
+				t.Errorf("got %s, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActiveBinds(t *testing.T) {
+	binds := []bind{{AsgID: types.StringValue("asg-1"), SpaceID: types.StringValue("space-1")}}
+	tests := []struct {
+		name    string
+		expired types.Bool
+		want    int
+	}{
+		{name: "not expired", expired: types.BoolValue(false), want: 1},
+		{name: "expired", expired: types.BoolValue(true), want: 0},
+		{name: "not set", expired: types.BoolNull(), want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := activeBinds(binds, tt.expired); len(got) != tt.want {
+				t.Errorf("got %d binds, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestGetListedBindingsLifecycle(t *testing.T) {
+	var secGroup client.SecurityGroup
+	secGroup.GUID, secGroup.Name = "asg-1", "platform"
+	secGroup.Relationships.Running_Spaces.Data = []client.Data{{GUID: "space-both"}, {GUID: "space-running"}}
+	secGroup.Relationships.Staging_Spaces.Data = []client.Data{{GUID: "space-both"}, {GUID: "space-staging"}}
+
+	listed, err := getListedBindings(nil, client.SecurityGroups{Resources: []client.SecurityGroup{secGroup}}, "", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := map[string]string{"space-both": "", "space-running": lifecycleRunning, "space-staging": lifecycleStaging}
+	if len(listed) != len(want) {
+		t.Fatalf("got bindings %+v, want one per space of %v", listed, want)
+	}
+	for _, binding := range listed {
+		if lifecycle, ok := want[binding.SpaceID]; !ok || binding.Lifecycle != lifecycle {
+			t.Errorf("got lifecycle %q for space %s, want %q", binding.Lifecycle, binding.SpaceID, lifecycle)
+		}
+	}
+}
+
+// newTestCCV3Client return a cloud controller client sending requests to handler
+func newTestCCV3Client(t *testing.T, handler http.HandlerFunc) *ccv3.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	ccv3Client := ccv3.NewClient(ccv3.Config{AppName: "test"})
+	ccv3Client.TargetCF(ccv3.TargetSettings{URL: server.URL})
+	return ccv3Client
+}
+
+// newTestClient return a cfsecurity client using accessToken and sending cloud controller requests to handler
+func newTestClient(t *testing.T, accessToken string, handler http.HandlerFunc) *client.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return client.NewClient(server.URL, nil, accessToken, server.URL, &http.Transport{})
+}
+
+func TestCheckDisruptionOnExpiry(t *testing.T) {
+	ccv3Client := newTestCCV3Client(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/apps" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"pagination":{"next":null},"resources":[
+			{"guid":"app-1","name":"started","state":"STARTED","relationships":{"space":{"data":{"guid":"space-1"}}}},
+			{"guid":"app-2","name":"stopped","state":"STOPPED","relationships":{"space":{"data":{"guid":"space-1"}}}}
+		]}`))
+	})
+	removed := []bind{{AsgID: types.StringValue("asg-1"), SpaceID: types.StringValue("space-1")}}
+
+	tests := []struct {
+		name            string
+		allowDisruption types.Bool
+		expired         bool
+		want            diag.Severity
+	}{
+		{name: "removed by user", allowDisruption: types.BoolNull(), want: diag.SeverityError},
+		{name: "removed by user and disruption not allowed", allowDisruption: types.BoolValue(false), want: diag.SeverityError},
+		{name: "removed by user and disruption allowed", allowDisruption: types.BoolValue(true), want: diag.SeverityWarning},
+		{name: "expired", allowDisruption: types.BoolNull(), expired: true, want: diag.SeverityWarning},
+		{name: "expired and disruption not allowed", allowDisruption: types.BoolValue(false), expired: true, want: diag.SeverityWarning},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			disrupted, diags := checkDisruption(ccv3Client, removed, disruptionAllowed(tt.allowDisruption, tt.expired))
+			if len(disrupted) != 1 || disrupted[0].AppID.ValueString() != "app-1" {
+				t.Fatalf("got disrupted apps %v, want the started app only", disrupted)
+			}
+			if len(diags) != 1 || diags[0].Severity() != tt.want || diags[0].Summary() != "Disruptive Unbind" {
+				t.Errorf("got %v, want a %s about disruptive unbind", diags, tt.want)
+			}
+		})
+	}
+}
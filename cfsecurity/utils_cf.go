@@ -0,0 +1,299 @@
+package cfsecurity
+
+import (
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3/constant"
+	"code.cloudfoundry.org/cli/v8/resources"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
+	"github.com/thoas/go-funk"
+)
+
+// getUserManagedGUIDs return guids of orgs where user is org manager and guids of spaces where user is space manager
+func getUserManagedGUIDs(ccv3Client *ccv3.Client, userGUID string) (orgGUIDs []string, spaceGUIDs []string, err error) {
+	roles, _, _, err := ccv3Client.GetRoles(
+		ccv3.Query{Key: ccv3.UserGUIDFilter, Values: []string{userGUID}},
+		ccv3.Query{Key: ccv3.RoleTypesFilter, Values: []string{string(constant.OrgManagerRole), string(constant.SpaceManagerRole)}},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	orgGUIDs = make([]string, 0)
+	spaceGUIDs = make([]string, 0)
+	for _, role := range roles {
+		switch role.Type {
+		case constant.OrgManagerRole:
+			if !funk.ContainsString(orgGUIDs, role.OrgGUID) {
+				orgGUIDs = append(orgGUIDs, role.OrgGUID)
+			}
+		case constant.SpaceManagerRole:
+			if !funk.ContainsString(spaceGUIDs, role.SpaceGUID) {
+				spaceGUIDs = append(spaceGUIDs, role.SpaceGUID)
+			}
+		}
+	}
+	return orgGUIDs, spaceGUIDs, nil
+}
+
+// chunkStrings split values into chunks of at most size elements to keep urls of filtered requests short
+func chunkStrings(values []string, size int) [][]string {
+	chunks := make([][]string, 0)
+	for i := 0; i < len(values); i += size {
+		end := i + size
+		if end > len(values) {
+			end = len(values)
+		}
+		chunks = append(chunks, values[i:end])
+	}
+	return chunks
+}
+
+// getUsersByGUID return users indexed by their guid
+func getUsersByGUID(ccv3Client *ccv3.Client, userGUIDs []string) (map[string]resources.User, error) {
+	users := make(map[string]resources.User)
+	for _, chunk := range chunkStrings(userGUIDs, 50) {
+		chunkUsers, _, err := ccv3Client.GetUsers(ccv3.Query{Key: ccv3.GUIDFilter, Values: chunk})
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range chunkUsers {
+			users[user.GUID] = user
+		}
+	}
+	return users, nil
+}
+
+// metadataToMaps convert cloud foundry metadata into labels and annotations maps
+func metadataToMaps(metadata *resources.Metadata) (labels map[string]string, annotations map[string]string) {
+	labels = make(map[string]string)
+	annotations = make(map[string]string)
+	if metadata == nil {
+		return labels, annotations
+	}
+	for key, value := range metadata.Labels {
+		if value.IsSet {
+			labels[key] = value.Value
+		}
+	}
+	for key, value := range metadata.Annotations {
+		if value.IsSet {
+			annotations[key] = value.Value
+		}
+	}
+	return labels, annotations
+}
+
+// getSpaces return spaces matching queries
+func getSpaces(clt *client.Client, queries []ccv3.Query) ([]client.Space, error) {
+	spaces, err := clt.GetSpacesWithOrg(queries, 0)
+	if err != nil {
+		return nil, err
+	}
+	return spaces.Resources, nil
+}
+
+// checkSecurityGroupsExist add an error on each path referencing a security group which does not exist or is not visible to the user,
+// paths are indexed by security group guid
+func checkSecurityGroupsExist(clt *client.Client, guidPaths map[string][]path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(validGUIDs(guidPaths)) == 0 {
+		return diags
+	}
+
+	secGroups, err := clt.GetSecGroups([]ccv3.Query{}, 0)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get security groups : %s", err),
+		)
+		return diags
+	}
+	found := make(map[string]bool)
+	for _, secGroup := range secGroups.Resources {
+		found[secGroup.GUID] = true
+	}
+	addNotFoundErrors(&diags, "Security group", guidPaths, found)
+	return diags
+}
+
+// checkSpacesExist add an error on each path referencing a space which does not exist or is not visible to the user,
+// paths are indexed by space guid
+func checkSpacesExist(clt *client.Client, guidPaths map[string][]path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	found := make(map[string]bool)
+	for _, chunk := range chunkStrings(validGUIDs(guidPaths), 50) {
+		spaces, err := clt.GetSpacesWithOrg([]ccv3.Query{{Key: ccv3.GUIDFilter, Values: chunk}}, 0)
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to get spaces : %s", err),
+			)
+			return diags
+		}
+		for _, space := range spaces.Resources {
+			found[space.GUID] = true
+		}
+	}
+	addNotFoundErrors(&diags, "Space", guidPaths, found)
+	return diags
+}
+
+// checkOrgsExist add an error on each path referencing an org which does not exist or is not visible to the user,
+// paths are indexed by org guid
+func checkOrgsExist(ccv3Client *ccv3.Client, guidPaths map[string][]path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	found := make(map[string]bool)
+	for _, chunk := range chunkStrings(validGUIDs(guidPaths), 50) {
+		orgs, _, err := ccv3Client.GetOrganizations(ccv3.Query{Key: ccv3.GUIDFilter, Values: chunk})
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to get orgs : %s", err),
+			)
+			return diags
+		}
+		for _, org := range orgs {
+			found[org.GUID] = true
+		}
+	}
+	addNotFoundErrors(&diags, "Org", guidPaths, found)
+	return diags
+}
+
+// validGUIDs return guids of paths which are syntactically valid, invalid ones are already reported by validators
+func validGUIDs(guidPaths map[string][]path.Path) []string {
+	guids := make([]string, 0, len(guidPaths))
+	for guid := range guidPaths {
+		if isGUID(guid) {
+			guids = append(guids, guid)
+		}
+	}
+	sort.Strings(guids)
+	return guids
+}
+
+func addNotFoundErrors(diags *diag.Diagnostics, kind string, guidPaths map[string][]path.Path, found map[string]bool) {
+	for _, guid := range validGUIDs(guidPaths) {
+		if found[guid] {
+			continue
+		}
+		for _, p := range guidPaths[guid] {
+			diags.AddAttributeError(
+				p,
+				"Not Found",
+				fmt.Sprintf("%s %s does not exist or is not visible to the current user", kind, guid),
+			)
+		}
+	}
+}
+
+// checkUserManagesSpaces add an error on each path referencing a space the current user can not bind security groups to,
+// user must be org manager of the space org or space manager of the space, admins can bind to any space,
+// paths are indexed by space guid
+func checkUserManagesSpaces(clt *client.Client, ccv3Client *ccv3.Client, guidPaths map[string][]path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	spaceGUIDs := validGUIDs(guidPaths)
+	if len(spaceGUIDs) == 0 {
+		return diags
+	}
+
+	userIsAdmin, err := clt.CurrentUserIsAdmin()
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to check if user is admin: %s", err),
+		)
+		return diags
+	}
+	if userIsAdmin {
+		return diags
+	}
+
+	claims, err := getClaimsFromToken(*clt.GetAccessToken())
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to decode access token: %s", err),
+		)
+		return diags
+	}
+	managedOrgIDs, managedSpaceIDs, err := getUserManagedGUIDs(ccv3Client, claims.Sub)
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get roles of current user: %s", err),
+		)
+		return diags
+	}
+
+	for _, chunk := range chunkStrings(spaceGUIDs, 50) {
+		spaces, err := clt.GetSpacesWithOrg([]ccv3.Query{{Key: ccv3.GUIDFilter, Values: chunk}}, 0)
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to get spaces : %s", err),
+			)
+			return diags
+		}
+		for _, space := range spaces.Resources {
+			orgGUID := space.Relationships[constant.RelationshipTypeOrganization].GUID
+			if funk.ContainsString(managedOrgIDs, orgGUID) || funk.ContainsString(managedSpaceIDs, space.GUID) {
+				continue
+			}
+			for _, p := range guidPaths[space.GUID] {
+				diags.AddAttributeError(
+					p,
+					"Missing Role",
+					fmt.Sprintf("User %s must have role %s on org %s or role %s on space %s (%s) to bind security groups to it",
+						claims.UserName, constant.OrgManagerRole, orgGUID, constant.SpaceManagerRole, space.Name, space.GUID),
+				)
+			}
+		}
+	}
+	return diags
+}
+
+// securityGroupUpdate is the body of a security group update, unlike resources.SecurityGroup the name is sent
+// and an empty list of rules is not omitted
+type securityGroupUpdate struct {
+	Name            string                       `json:"name"`
+	Rules           []resources.Rule             `json:"rules"`
+	GloballyEnabled securityGroupGloballyEnabled `json:"globally_enabled"`
+}
+
+type securityGroupGloballyEnabled struct {
+	Running *bool `json:"running,omitempty"`
+	Staging *bool `json:"staging,omitempty"`
+}
+
+// updateSecurityGroup update name, rules and global enablement of a security group,
+// UpdateSecurityGroup of the cli client can not be used as it never sends the name
+func updateSecurityGroup(ccv3Client *ccv3.Client, guid string, update securityGroupUpdate) (resources.SecurityGroup, error) {
+	if update.Rules == nil {
+		update.Rules = []resources.Rule{}
+	}
+	var secGroup resources.SecurityGroup
+	_, _, err := ccv3Client.MakeRequest(ccv3.RequestParams{
+		RequestName:  "PatchSecurityGroup",
+		URIParams:    map[string]string{"security_group_guid": guid},
+		RequestBody:  update,
+		ResponseBody: &secGroup,
+	})
+	return secGroup, err
+}
+
+// ccResourceExists return false when the cloud controller answers not found for the resource at url
+func ccResourceExists(ccv3Client *ccv3.Client, url string) (bool, error) {
+	_, _, err := ccv3Client.MakeRequest(ccv3.RequestParams{
+		URL:          url,
+		ResponseBody: &map[string]interface{}{},
+	})
+	if isCCNotFoundErr(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
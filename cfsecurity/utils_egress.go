@@ -0,0 +1,457 @@
+package cfsecurity
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccv3"
+	"code.cloudfoundry.org/cli/v8/resources"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	lifecycleRunning = "running"
+	lifecycleStaging = "staging"
+
+	protocolAll    = "all"
+	protocolTCP    = "tcp"
+	protocolUDP    = "udp"
+	protocolICMP   = "icmp"
+	protocolICMPv6 = "icmpv6"
+
+	// icmpAny is the value used by cloud controller for "any icmp type/code"
+	icmpAny = -1
+)
+
+type portRange struct {
+	Start uint16
+	End   uint16
+}
+
+type egressSource struct {
+	GUID string
+	Name string
+}
+
+// egressRule is a security group rule reduced to a single destination interval
+// so that rules can be compared, merged and evaluated
+type egressRule struct {
+	Protocol    string
+	DestStart   netip.Addr
+	DestEnd     netip.Addr
+	Ports       []portRange
+	Type        int
+	Code        int
+	Description string
+	Sources     []egressSource
+}
+
+// isValidLifecycle return true if lifecycle is unset or is one of running or staging
+func isValidLifecycle(lifecycle types.String) bool {
+	if lifecycle.IsNull() || lifecycle.IsUnknown() {
+		return true
+	}
+	return lifecycle.ValueString() == lifecycleRunning || lifecycle.ValueString() == lifecycleStaging
+}
+
+// getSpaceSecurityGroups return security groups applying to a space for the given lifecycle,
+// this includes security groups bound to the space and globally enabled ones
+func getSpaceSecurityGroups(ccv3Client *ccv3.Client, spaceGUID string, lifecycle string) ([]resources.SecurityGroup, error) {
+	if lifecycle == lifecycleStaging {
+		secGroups, _, err := ccv3Client.GetStagingSecurityGroups(spaceGUID)
+		return secGroups, err
+	}
+	secGroups, _, err := ccv3Client.GetRunningSecurityGroups(spaceGUID)
+	return secGroups, err
+}
+
+// parseSecurityGroupRules convert rules of a security group into egress rules,
+// a rule with multiple destinations is split into one egress rule per destination
+func parseSecurityGroupRules(secGroup resources.SecurityGroup) ([]egressRule, error) {
+	rules := make([]egressRule, 0)
+	for _, rule := range secGroup.Rules {
+		protocol := strings.ToLower(strings.TrimSpace(rule.Protocol))
+		var ports []portRange
+		if protocol == protocolTCP || protocol == protocolUDP {
+			p := ""
+			if rule.Ports != nil {
+				p = *rule.Ports
+			}
+			var err error
+			ports, err = parsePorts(p)
+			if err != nil {
+				return nil, fmt.Errorf("security group %s: %s", secGroup.Name, err)
+			}
+		}
+		ruleType, ruleCode := icmpAny, icmpAny
+		if protocol == protocolICMP || protocol == protocolICMPv6 {
+			if rule.Type != nil {
+				ruleType = *rule.Type
+			}
+			if rule.Code != nil {
+				ruleCode = *rule.Code
+			}
+		}
+		description := ""
+		if rule.Description != nil {
+			description = *rule.Description
+		}
+		for _, destination := range strings.Split(rule.Destination, ",") {
+			start, end, err := parseDestination(destination)
+			if err != nil {
+				return nil, fmt.Errorf("security group %s: %s", secGroup.Name, err)
+			}
+			rules = append(rules, egressRule{
+				Protocol:    protocol,
+				DestStart:   start,
+				DestEnd:     end,
+				Ports:       ports,
+				Type:        ruleType,
+				Code:        ruleCode,
+				Description: description,
+				Sources:     []egressSource{{GUID: secGroup.GUID, Name: secGroup.Name}},
+			})
+		}
+	}
+	return rules, nil
+}
+
+// parseDestination parse a single ip, an ip range (ip1-ip2) or a cidr into an interval of addresses
+func parseDestination(destination string) (netip.Addr, netip.Addr, error) {
+	destination = strings.TrimSpace(destination)
+	if strings.Contains(destination, "/") {
+		prefix, err := netip.ParsePrefix(destination)
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid destination %q: %s", destination, err)
+		}
+		prefix = prefix.Masked()
+		return prefix.Addr().Unmap(), lastAddr(prefix), nil
+	}
+	if startS, endS, found := strings.Cut(destination, "-"); found {
+		start, err := netip.ParseAddr(strings.TrimSpace(startS))
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid destination %q: %s", destination, err)
+		}
+		end, err := netip.ParseAddr(strings.TrimSpace(endS))
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid destination %q: %s", destination, err)
+		}
+		start, end = start.Unmap(), end.Unmap()
+		if start.Is4() != end.Is4() || end.Less(start) {
+			return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid destination range %q", destination)
+		}
+		return start, end, nil
+	}
+	addr, err := netip.ParseAddr(destination)
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid destination %q: %s", destination, err)
+	}
+	addr = addr.Unmap()
+	return addr, addr, nil
+}
+
+// lastAddr return the last address of a prefix
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Masked().Addr().Unmap()
+	b := addr.AsSlice()
+	bits := prefix.Bits()
+	if addr.Is4() && prefix.Addr().Is4In6() {
+		bits -= 96
+	}
+	for i := bits; i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - uint(i%8))
+	}
+	last, _ := netip.AddrFromSlice(b)
+	return last
+}
+
+// formatDestination give the shortest cloud foundry notation of an interval of addresses
+func formatDestination(start, end netip.Addr) string {
+	if start == end {
+		return start.String()
+	}
+	for bits := start.BitLen(); bits >= 0; bits-- {
+		prefix := netip.PrefixFrom(start, bits)
+		if prefix.Masked().Addr() != start {
+			break
+		}
+		if lastAddr(prefix) == end {
+			return prefix.String()
+		}
+	}
+	return start.String() + "-" + end.String()
+}
+
+// parsePorts parse ports as given in a security group rule (e.g.: "80", "8080-8090", "80,443")
+func parsePorts(ports string) ([]portRange, error) {
+	ranges := make([]portRange, 0)
+	for _, p := range strings.Split(ports, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		startS, endS, found := strings.Cut(p, "-")
+		if !found {
+			endS = startS
+		}
+		start, err := strconv.ParseUint(strings.TrimSpace(startS), 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ports %q", ports)
+		}
+		end, err := strconv.ParseUint(strings.TrimSpace(endS), 10, 16)
+		if err != nil || end < start {
+			return nil, fmt.Errorf("invalid ports %q", ports)
+		}
+		ranges = append(ranges, portRange{Start: uint16(start), End: uint16(end)})
+	}
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("ports must be given")
+	}
+	return mergePortRanges(ranges), nil
+}
+
+// mergePortRanges sort port ranges and merge overlapping or adjacent ones
+func mergePortRanges(ranges []portRange) []portRange {
+	sorted := append([]portRange{}, ranges...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+	merged := make([]portRange, 0, len(sorted))
+	for _, r := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && uint32(r.Start) <= uint32(merged[last].End)+1 {
+			if r.End > merged[last].End {
+				merged[last].End = r.End
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+func formatPorts(ranges []portRange) string {
+	ports := make([]string, len(ranges))
+	for i, r := range ranges {
+		if r.Start == r.End {
+			ports[i] = strconv.Itoa(int(r.Start))
+			continue
+		}
+		ports[i] = fmt.Sprintf("%d-%d", r.Start, r.End)
+	}
+	return strings.Join(ports, ",")
+}
+
+func portsContain(ranges []portRange, port uint16) bool {
+	for _, r := range ranges {
+		if port >= r.Start && port <= r.End {
+			return true
+		}
+	}
+	return false
+}
+
+// portsCover return true if all ports from others are in ranges
+func portsCover(ranges []portRange, others []portRange) bool {
+	for _, o := range others {
+		covered := false
+		for _, r := range ranges {
+			if o.Start >= r.Start && o.End <= r.End {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+func mergeSources(sources []egressSource, others []egressSource) []egressSource {
+	merged := append([]egressSource{}, sources...)
+	for _, other := range others {
+		if !isInSlice(merged, func(object interface{}) bool {
+			return object.(egressSource).GUID == other.GUID
+		}) {
+			merged = append(merged, other)
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].Name == merged[j].Name {
+			return merged[i].GUID < merged[j].GUID
+		}
+		return merged[i].Name < merged[j].Name
+	})
+	return merged
+}
+
+func (r egressRule) hasPorts() bool {
+	return r.Protocol == protocolTCP || r.Protocol == protocolUDP
+}
+
+func (r egressRule) hasICMP() bool {
+	return r.Protocol == protocolICMP || r.Protocol == protocolICMPv6
+}
+
+func (r egressRule) destinationContains(other egressRule) bool {
+	return r.DestStart.Is4() == other.DestStart.Is4() &&
+		!other.DestStart.Less(r.DestStart) && !r.DestEnd.Less(other.DestEnd)
+}
+
+// covers return true if every packet allowed by other is also allowed by r
+func (r egressRule) covers(other egressRule) bool {
+	if !r.destinationContains(other) {
+		return false
+	}
+	if r.Protocol == protocolAll {
+		return true
+	}
+	if r.Protocol != other.Protocol {
+		return false
+	}
+	if r.hasPorts() {
+		return portsCover(r.Ports, other.Ports)
+	}
+	if r.hasICMP() {
+		return (r.Type == icmpAny || r.Type == other.Type) && (r.Code == icmpAny || r.Code == other.Code)
+	}
+	return true
+}
+
+// allows return true if traffic to addr on the given protocol, port and icmp type/code is allowed by the rule
+func (r egressRule) allows(protocol string, addr netip.Addr, port uint16, icmpType, icmpCode int) bool {
+	if addr.Is4() != r.DestStart.Is4() || addr.Less(r.DestStart) || r.DestEnd.Less(addr) {
+		return false
+	}
+	if r.Protocol == protocolAll {
+		return true
+	}
+	if r.Protocol != protocol {
+		return false
+	}
+	if r.hasPorts() {
+		return portsContain(r.Ports, port)
+	}
+	if r.hasICMP() {
+		return (r.Type == icmpAny || r.Type == icmpType) && (r.Code == icmpAny || r.Code == icmpCode)
+	}
+	return true
+}
+
+func (r egressRule) Destination() string {
+	return formatDestination(r.DestStart, r.DestEnd)
+}
+
+// filterKey identify rules which only differ by their destination
+func (r egressRule) filterKey() string {
+	return fmt.Sprintf("%s|%t|%s|%d|%d", r.Protocol, r.DestStart.Is4(), formatPorts(r.Ports), r.Type, r.Code)
+}
+
+// normalizeEgressRules merge and deduplicate rules, the result is the smallest set of rules found
+// allowing the same traffic than given rules, each rule keep track of security groups allowing it
+func normalizeEgressRules(rules []egressRule) []egressRule {
+	normalized := make([]egressRule, len(rules))
+	for i, rule := range rules {
+		rule.Description = ""
+		if !rule.hasPorts() {
+			rule.Ports = nil
+		}
+		if !rule.hasICMP() {
+			rule.Type, rule.Code = icmpAny, icmpAny
+		}
+		normalized[i] = rule
+	}
+	for {
+		var destChanged, portChanged, coverChanged bool
+		normalized, destChanged = mergeEgressDestinations(normalized)
+		normalized, portChanged = mergeEgressPorts(normalized)
+		normalized, coverChanged = removeCoveredEgressRules(normalized)
+		if !destChanged && !portChanged && !coverChanged {
+			break
+		}
+	}
+	sort.Slice(normalized, func(i, j int) bool {
+		a, b := normalized[i], normalized[j]
+		if a.Protocol != b.Protocol {
+			return a.Protocol < b.Protocol
+		}
+		if a.DestStart != b.DestStart {
+			return a.DestStart.Less(b.DestStart)
+		}
+		if a.DestEnd != b.DestEnd {
+			return a.DestEnd.Less(b.DestEnd)
+		}
+		return a.filterKey() < b.filterKey()
+	})
+	return normalized
+}
+
+// mergeEgressDestinations merge rules with overlapping or adjacent destinations
+func mergeEgressDestinations(rules []egressRule) ([]egressRule, bool) {
+	sorted := append([]egressRule{}, rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].filterKey() != sorted[j].filterKey() {
+			return sorted[i].filterKey() < sorted[j].filterKey()
+		}
+		return sorted[i].DestStart.Less(sorted[j].DestStart)
+	})
+	merged := make([]egressRule, 0, len(sorted))
+	for _, rule := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && merged[last].filterKey() == rule.filterKey() {
+			next := merged[last].DestEnd.Next()
+			if !next.IsValid() || !next.Less(rule.DestStart) {
+				if merged[last].DestEnd.Less(rule.DestEnd) {
+					merged[last].DestEnd = rule.DestEnd
+				}
+				merged[last].Sources = mergeSources(merged[last].Sources, rule.Sources)
+				continue
+			}
+		}
+		merged = append(merged, rule)
+	}
+	return merged, len(merged) != len(rules)
+}
+
+// mergeEgressPorts merge port ranges of tcp/udp rules targeting the same destination
+func mergeEgressPorts(rules []egressRule) ([]egressRule, bool) {
+	merged := make([]egressRule, 0, len(rules))
+	for _, rule := range rules {
+		found := false
+		if rule.hasPorts() {
+			for i := range merged {
+				if merged[i].Protocol == rule.Protocol && merged[i].DestStart == rule.DestStart && merged[i].DestEnd == rule.DestEnd {
+					merged[i].Ports = mergePortRanges(append(append([]portRange{}, merged[i].Ports...), rule.Ports...))
+					merged[i].Sources = mergeSources(merged[i].Sources, rule.Sources)
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			merged = append(merged, rule)
+		}
+	}
+	return merged, len(merged) != len(rules)
+}
+
+// removeCoveredEgressRules remove rules for which traffic is already allowed by another rule
+func removeCoveredEgressRules(rules []egressRule) ([]egressRule, bool) {
+	kept := append([]egressRule{}, rules...)
+	changed := false
+	for i := 0; i < len(kept); i++ {
+		for j := 0; j < len(kept); j++ {
+			if i == j || !kept[j].covers(kept[i]) {
+				continue
+			}
+			kept[j].Sources = mergeSources(kept[j].Sources, kept[i].Sources)
+			kept = append(kept[:i], kept[i+1:]...)
+			changed = true
+			i--
+			break
+		}
+	}
+	return kept, changed
+}
@@ -0,0 +1,356 @@
+package cfsecurity
+
+import (
+	"net/netip"
+	"testing"
+
+	"code.cloudfoundry.org/cli/v8/resources"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func mustEgressRule(t *testing.T, protocol, destination, ports string, icmpType, icmpCode int) egressRule {
+	t.Helper()
+	start, end, err := parseDestination(destination)
+	if err != nil {
+		t.Fatalf("parseDestination(%q): %s", destination, err)
+	}
+	rule := egressRule{Protocol: protocol, DestStart: start, DestEnd: end, Type: icmpType, Code: icmpCode}
+	if ports != "" {
+		rule.Ports, err = parsePorts(ports)
+		if err != nil {
+			t.Fatalf("parsePorts(%q): %s", ports, err)
+		}
+	}
+	return rule
+}
+
+func TestParseDestination(t *testing.T) {
+	tests := []struct {
+		destination string
+		start       string
+		end         string
+		wantErr     bool
+	}{
+		{destination: "10.0.0.1", start: "10.0.0.1", end: "10.0.0.1"},
+		{destination: " 10.0.0.1 ", start: "10.0.0.1", end: "10.0.0.1"},
+		{destination: "10.0.0.0/24", start: "10.0.0.0", end: "10.0.0.255"},
+		{destination: "10.0.0.12/24", start: "10.0.0.0", end: "10.0.0.255"},
+		{destination: "0.0.0.0/0", start: "0.0.0.0", end: "255.255.255.255"},
+		{destination: "10.0.0.1-10.0.0.9", start: "10.0.0.1", end: "10.0.0.9"},
+		{destination: "::ffff:10.0.0.1", start: "10.0.0.1", end: "10.0.0.1"},
+		{destination: "2001:db8::/120", start: "2001:db8::", end: "2001:db8::ff"},
+		{destination: "10.0.0.9-10.0.0.1", wantErr: true},
+		{destination: "10.0.0.1-2001:db8::1", wantErr: true},
+		{destination: "10.0.0.0/33", wantErr: true},
+		{destination: "not-an-ip", wantErr: true},
+		{destination: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.destination, func(t *testing.T) {
+			start, end, err := parseDestination(tt.destination)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %s-%s", start, end)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if start.String() != tt.start || end.String() != tt.end {
+				t.Errorf("got %s-%s, want %s-%s", start, end, tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestFormatDestination(t *testing.T) {
+	tests := []struct {
+		start string
+		end   string
+		want  string
+	}{
+		{start: "10.0.0.1", end: "10.0.0.1", want: "10.0.0.1"},
+		{start: "10.0.0.0", end: "10.0.0.255", want: "10.0.0.0/24"},
+		{start: "10.0.0.0", end: "10.0.0.1", want: "10.0.0.0/31"},
+		{start: "10.0.0.1", end: "10.0.0.2", want: "10.0.0.1-10.0.0.2"},
+		{start: "10.0.0.0", end: "10.0.0.2", want: "10.0.0.0-10.0.0.2"},
+		{start: "0.0.0.0", end: "255.255.255.255", want: "0.0.0.0/0"},
+		{start: "::", end: "::ffff", want: "::/112"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got := formatDestination(netip.MustParseAddr(tt.start), netip.MustParseAddr(tt.end))
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePorts(t *testing.T) {
+	tests := []struct {
+		ports   string
+		want    string
+		wantErr bool
+	}{
+		{ports: "80", want: "80"},
+		{ports: "80,443", want: "80,443"},
+		{ports: "443, 80", want: "80,443"},
+		{ports: "8080-8090", want: "8080-8090"},
+		{ports: "80,81,82", want: "80-82"},
+		{ports: "80-90,85-100", want: "80-100"},
+		{ports: "1-65535", want: "1-65535"},
+		{ports: "", wantErr: true},
+		{ports: "90-80", wantErr: true},
+		{ports: "65536", wantErr: true},
+		{ports: "http", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ports, func(t *testing.T) {
+			ranges, err := parsePorts(tt.ports)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %s", formatPorts(ranges))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := formatPorts(ranges); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSecurityGroupRules(t *testing.T) {
+	secGroup := resources.SecurityGroup{
+		Name: "platform",
+		GUID: "guid-platform",
+		Rules: []resources.Rule{
+			{Protocol: "TCP", Destination: "10.0.0.1,10.0.1.0/24", Ports: strPtr("443"), Description: strPtr("https")},
+			{Protocol: "icmp", Destination: "10.0.0.1", Type: intPtr(8), Code: intPtr(0)},
+			{Protocol: "all", Destination: "192.168.0.0/16", Ports: strPtr("80")},
+		},
+	}
+	rules, err := parseSecurityGroupRules(secGroup)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(rules) != 4 {
+		t.Fatalf("got %d rules, want 4", len(rules))
+	}
+	if rules[0].Protocol != protocolTCP || rules[0].Destination() != "10.0.0.1" || formatPorts(rules[0].Ports) != "443" || rules[0].Description != "https" {
+		t.Errorf("unexpected first rule %+v", rules[0])
+	}
+	if rules[1].Destination() != "10.0.1.0/24" {
+		t.Errorf("got destination %s, want 10.0.1.0/24", rules[1].Destination())
+	}
+	if rules[2].Type != 8 || rules[2].Code != 0 {
+		t.Errorf("got icmp %d/%d, want 8/0", rules[2].Type, rules[2].Code)
+	}
+	if rules[3].Ports != nil || rules[3].Type != icmpAny || rules[3].Code != icmpAny {
+		t.Errorf("ports and icmp must be ignored for protocol all, got %+v", rules[3])
+	}
+	for _, rule := range rules {
+		if len(rule.Sources) != 1 || rule.Sources[0].GUID != "guid-platform" {
+			t.Errorf("unexpected sources %+v", rule.Sources)
+		}
+	}
+
+	_, err = parseSecurityGroupRules(resources.SecurityGroup{
+		Name:  "broken",
+		Rules: []resources.Rule{{Protocol: "tcp", Destination: "10.0.0.1"}},
+	})
+	if err == nil {
+		t.Error("expected an error for a tcp rule without ports")
+	}
+}
+
+func TestEgressRuleCovers(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  egressRule
+		other egressRule
+		want  bool
+	}{
+		{
+			name:  "all covers any protocol in destination",
+			rule:  mustEgressRule(t, protocolAll, "10.0.0.0/8", "", icmpAny, icmpAny),
+			other: mustEgressRule(t, protocolTCP, "10.0.0.1", "443", icmpAny, icmpAny),
+			want:  true,
+		},
+		{
+			name:  "destination outside",
+			rule:  mustEgressRule(t, protocolAll, "10.0.0.0/24", "", icmpAny, icmpAny),
+			other: mustEgressRule(t, protocolTCP, "10.0.0.0/23", "443", icmpAny, icmpAny),
+			want:  false,
+		},
+		{
+			name:  "different protocol",
+			rule:  mustEgressRule(t, protocolUDP, "10.0.0.1", "53", icmpAny, icmpAny),
+			other: mustEgressRule(t, protocolTCP, "10.0.0.1", "53", icmpAny, icmpAny),
+			want:  false,
+		},
+		{
+			name:  "port range covers ports",
+			rule:  mustEgressRule(t, protocolTCP, "10.0.0.1", "80-443", icmpAny, icmpAny),
+			other: mustEgressRule(t, protocolTCP, "10.0.0.1", "80,443", icmpAny, icmpAny),
+			want:  true,
+		},
+		{
+			name:  "port range partially covers ports",
+			rule:  mustEgressRule(t, protocolTCP, "10.0.0.1", "80-443", icmpAny, icmpAny),
+			other: mustEgressRule(t, protocolTCP, "10.0.0.1", "443-444", icmpAny, icmpAny),
+			want:  false,
+		},
+		{
+			name:  "icmp wildcard covers any type",
+			rule:  mustEgressRule(t, protocolICMP, "10.0.0.1", "", icmpAny, icmpAny),
+			other: mustEgressRule(t, protocolICMP, "10.0.0.1", "", 8, 0),
+			want:  true,
+		},
+		{
+			name:  "icmp type does not cover wildcard",
+			rule:  mustEgressRule(t, protocolICMP, "10.0.0.1", "", 8, 0),
+			other: mustEgressRule(t, protocolICMP, "10.0.0.1", "", icmpAny, icmpAny),
+			want:  false,
+		},
+		{
+			name:  "ipv4 does not cover ipv6",
+			rule:  mustEgressRule(t, protocolAll, "0.0.0.0/0", "", icmpAny, icmpAny),
+			other: mustEgressRule(t, protocolAll, "::1", "", icmpAny, icmpAny),
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.covers(tt.other); got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEgressRuleAllows(t *testing.T) {
+	tcp := mustEgressRule(t, protocolTCP, "10.0.0.0/24", "80,443", icmpAny, icmpAny)
+	icmp := mustEgressRule(t, protocolICMP, "10.0.0.1", "", 8, icmpAny)
+	all := mustEgressRule(t, protocolAll, "10.0.0.1-10.0.0.5", "", icmpAny, icmpAny)
+	tests := []struct {
+		name     string
+		rule     egressRule
+		protocol string
+		addr     string
+		port     uint16
+		icmpType int
+		icmpCode int
+		want     bool
+	}{
+		{name: "tcp allowed port", rule: tcp, protocol: protocolTCP, addr: "10.0.0.10", port: 443, want: true},
+		{name: "tcp other port", rule: tcp, protocol: protocolTCP, addr: "10.0.0.10", port: 22, want: false},
+		{name: "tcp other destination", rule: tcp, protocol: protocolTCP, addr: "10.0.1.10", port: 443, want: false},
+		{name: "udp on tcp rule", rule: tcp, protocol: protocolUDP, addr: "10.0.0.10", port: 443, want: false},
+		{name: "icmp any code", rule: icmp, protocol: protocolICMP, addr: "10.0.0.1", icmpType: 8, icmpCode: 3, want: true},
+		{name: "icmp other type", rule: icmp, protocol: protocolICMP, addr: "10.0.0.1", icmpType: 0, icmpCode: 0, want: false},
+		{name: "icmp wildcard type on typed rule", rule: icmp, protocol: protocolICMP, addr: "10.0.0.1", icmpType: icmpAny, icmpCode: icmpAny, want: false},
+		{name: "all in range", rule: all, protocol: protocolUDP, addr: "10.0.0.5", port: 53, want: true},
+		{name: "all out of range", rule: all, protocol: protocolUDP, addr: "10.0.0.6", port: 53, want: false},
+		{name: "ipv6 on ipv4 rule", rule: all, protocol: protocolUDP, addr: "::1", port: 53, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rule.allows(tt.protocol, netip.MustParseAddr(tt.addr), tt.port, tt.icmpType, tt.icmpCode)
+			if got != tt.want {
+				t.Errorf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeEgressRules(t *testing.T) {
+	withSource := func(rule egressRule, guid string) egressRule {
+		rule.Sources = []egressSource{{GUID: guid, Name: guid}}
+		return rule
+	}
+	tests := []struct {
+		name  string
+		rules []egressRule
+		want  []string
+	}{
+		{
+			name: "adjacent destinations are merged",
+			rules: []egressRule{
+				withSource(mustEgressRule(t, protocolTCP, "10.0.0.0-10.0.0.127", "80", icmpAny, icmpAny), "a"),
+				withSource(mustEgressRule(t, protocolTCP, "10.0.0.128/25", "80", icmpAny, icmpAny), "b"),
+			},
+			want: []string{"tcp 10.0.0.0/24 80 a,b"},
+		},
+		{
+			name: "ports of a same destination are merged",
+			rules: []egressRule{
+				withSource(mustEgressRule(t, protocolTCP, "10.0.0.1", "80", icmpAny, icmpAny), "a"),
+				withSource(mustEgressRule(t, protocolTCP, "10.0.0.1", "81-100", icmpAny, icmpAny), "b"),
+			},
+			want: []string{"tcp 10.0.0.1 80-100 a,b"},
+		},
+		{
+			name: "distinct destinations are kept",
+			rules: []egressRule{
+				withSource(mustEgressRule(t, protocolTCP, "10.0.0.1", "80", icmpAny, icmpAny), "a"),
+				withSource(mustEgressRule(t, protocolTCP, "10.0.0.3", "80", icmpAny, icmpAny), "a"),
+			},
+			want: []string{"tcp 10.0.0.1 80 a", "tcp 10.0.0.3 80 a"},
+		},
+		{
+			name: "covered rules are removed and their sources kept",
+			rules: []egressRule{
+				withSource(mustEgressRule(t, protocolTCP, "10.0.0.5", "443", icmpAny, icmpAny), "a"),
+				withSource(mustEgressRule(t, protocolICMP, "10.0.0.6", "", 8, 0), "b"),
+				withSource(mustEgressRule(t, protocolAll, "10.0.0.0/24", "", icmpAny, icmpAny), "c"),
+			},
+			want: []string{"all 10.0.0.0/24  a,b,c"},
+		},
+		{
+			name: "ipv4 and ipv6 are not merged",
+			rules: []egressRule{
+				withSource(mustEgressRule(t, protocolAll, "0.0.0.0/0", "", icmpAny, icmpAny), "a"),
+				withSource(mustEgressRule(t, protocolAll, "::/0", "", icmpAny, icmpAny), "a"),
+			},
+			want: []string{"all 0.0.0.0/0  a", "all ::/0  a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized := normalizeEgressRules(tt.rules)
+			got := make([]string, 0, len(normalized))
+			for _, rule := range normalized {
+				sources := ""
+				for i, source := range rule.Sources {
+					if i > 0 {
+						sources += ","
+					}
+					sources += source.GUID
+				}
+				got = append(got, rule.Protocol+" "+rule.Destination()+" "+formatPorts(rule.Ports)+" "+sources)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got %q, want %q", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
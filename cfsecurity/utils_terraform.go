@@ -1,10 +1,18 @@
 package cfsecurity
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"reflect"
 
+	"code.cloudfoundry.org/cli/v8/api/cloudcontroller/ccerror"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/orange-cloudfoundry/cf-security-entitlement/v2/client"
 )
 
@@ -13,7 +21,7 @@ func getListBindChanges(old []bind, new []bind) (remove []bind, add []bind) {
 	for _, source := range old {
 		toDelete := true
 		for _, item := range new {
-			if source.AsgID == item.AsgID && source.SpaceID == item.SpaceID {
+			if source.AsgID == item.AsgID && source.SpaceID == item.SpaceID && source.Lifecycle.Equal(item.Lifecycle) {
 				toDelete = false
 				break
 			}
@@ -25,7 +33,7 @@ func getListBindChanges(old []bind, new []bind) (remove []bind, add []bind) {
 	for _, source := range new {
 		toAdd := true
 		for _, item := range old {
-			if source.AsgID == item.AsgID && source.SpaceID == item.SpaceID {
+			if source.AsgID == item.AsgID && source.SpaceID == item.SpaceID && source.Lifecycle.Equal(item.Lifecycle) {
 				toAdd = false
 				break
 			}
@@ -81,3 +89,86 @@ func isNotFoundErr(err error) bool {
 	}
 	return false
 }
+
+func isCCNotFoundErr(err error) bool {
+	var notFoundErr ccerror.ResourceNotFoundError
+	return errors.As(err, &notFoundErr)
+}
+
+// checkCurrentUserIsAdmin return an error diagnostic when the current user is not an admin
+func checkCurrentUserIsAdmin(clt *client.Client) diag.Diagnostics {
+	var diags diag.Diagnostics
+	userIsAdmin, err := clt.CurrentUserIsAdmin()
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to check if user is admin: %s", err),
+		)
+		return diags
+	}
+	if !userIsAdmin {
+		diags.AddError(
+			"Client Error",
+			"This resource requires cloud foundry admin permissions",
+		)
+	}
+	return diags
+}
+
+// addGUIDPath index path of a known guid attribute by its value, used to report errors of existence checks
+func addGUIDPath(guidPaths map[string][]path.Path, value types.String, p path.Path) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	guidPaths[value.ValueString()] = append(guidPaths[value.ValueString()], p)
+}
+
+// addSetGUIDPaths index paths of known guids of a set attribute by their value
+func addSetGUIDPaths(guidPaths map[string][]path.Path, set types.Set, p path.Path) {
+	if set.IsNull() || set.IsUnknown() {
+		return
+	}
+	for _, elem := range set.Elements() {
+		if value, ok := elem.(types.String); ok {
+			addGUIDPath(guidPaths, value, p.AtSetValue(value))
+		}
+	}
+}
+
+// checkIdentity return an error diagnostic when identity stored by terraform does not match the one derived from state,
+// which means state was changed outside of the provider. Nothing is checked when terraform did not store any identity
+func checkIdentity(ctx context.Context, current *tfsdk.ResourceIdentity, expected interface{}) diag.Diagnostics {
+	if current == nil || current.Raw.IsFullyNull() {
+		return nil
+	}
+	fromState := tfsdk.ResourceIdentity{
+		Schema: current.Schema,
+		Raw:    tftypes.NewValue(current.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := fromState.Set(ctx, expected)
+	if diags.HasError() {
+		return diags
+	}
+	if !current.Raw.Equal(fromState.Raw) {
+		diags.AddError(
+			"Identity Mismatch",
+			fmt.Sprintf("Identity %s does not match resource state %s, state has been changed outside of the provider, remove the resource from state and import it again", current.Raw, fromState.Raw),
+		)
+	}
+	return diags
+}
+
+// checkIdentityGUIDs return an error diagnostic for each value of identity attribute attr which is not a guid
+func checkIdentityGUIDs(attr string, guids ...string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, guid := range guids {
+		if !isGUID(guid) {
+			diags.AddAttributeError(
+				path.Root(attr),
+				"Invalid Identity",
+				fmt.Sprintf("%q is not a guid (e.g.: 7e0477b9-fff8-41b1-8fd8-969095ba62e5)", guid),
+			)
+		}
+	}
+	return diags
+}
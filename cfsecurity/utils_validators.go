@@ -0,0 +1,102 @@
+package cfsecurity
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var guidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// isGUID return true if value is a syntactically valid cloud foundry guid
+func isGUID(value string) bool {
+	return guidRegexp.MatchString(value)
+}
+
+// guidValidator validate that a string attribute is a guid
+type guidValidator struct{}
+
+var _ validator.String = guidValidator{}
+
+func (v guidValidator) Description(_ context.Context) string {
+	return "value must be a guid (e.g.: 7e0477b9-fff8-41b1-8fd8-969095ba62e5)"
+}
+
+func (v guidValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v guidValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if !isGUID(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid GUID",
+			fmt.Sprintf("%q is not valid, %s", req.ConfigValue.ValueString(), v.Description(ctx)),
+		)
+	}
+}
+
+// guidSetValidator validate that every element of a set of strings is a guid
+type guidSetValidator struct{}
+
+var _ validator.Set = guidSetValidator{}
+
+func (v guidSetValidator) Description(_ context.Context) string {
+	return "values must be guids (e.g.: 7e0477b9-fff8-41b1-8fd8-969095ba62e5)"
+}
+
+func (v guidSetValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v guidSetValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for _, elem := range req.ConfigValue.Elements() {
+		value, ok := elem.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if !isGUID(value.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtSetValue(value),
+				"Invalid GUID",
+				fmt.Sprintf("%q is not valid, %s", value.ValueString(), v.Description(ctx)),
+			)
+		}
+	}
+}
+
+// rfc3339Validator validate that a string attribute is a RFC3339 timestamp
+type rfc3339Validator struct{}
+
+var _ validator.String = rfc3339Validator{}
+
+func (v rfc3339Validator) Description(_ context.Context) string {
+	return "value must be a RFC3339 timestamp (e.g.: 2025-01-31T18:00:00Z)"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("%q is not valid, %s", req.ConfigValue.ValueString(), v.Description(ctx)),
+		)
+	}
+}